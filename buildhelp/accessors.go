@@ -0,0 +1,1322 @@
+// Code generated by accessorgen from gotk4 gtk/v4. DO NOT EDIT.
+
+package buildhelp
+
+import "github.com/diamondburned/gotk4/pkg/gtk/v4"
+
+//
+//-----------------------------------------------------------------[ OBJECTS ]--
+
+// AboutDialog get the named object as AboutDialog.
+func (b *BuildHelp) AboutDialog(name string) (w *gtk.AboutDialog) {
+	b.getO("AboutDialog", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.AboutDialog); return })
+	return w
+}
+
+// ActionBar get the named object as ActionBar.
+func (b *BuildHelp) ActionBar(name string) (w *gtk.ActionBar) {
+	b.getO("ActionBar", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ActionBar); return })
+	return w
+}
+
+// ActivateAction get the named object as ActivateAction.
+func (b *BuildHelp) ActivateAction(name string) (w *gtk.ActivateAction) {
+	b.getO("ActivateAction", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ActivateAction); return })
+	return w
+}
+
+// Adjustment get the named object as Adjustment.
+func (b *BuildHelp) Adjustment(name string) (w *gtk.Adjustment) {
+	b.getO("Adjustment", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Adjustment); return })
+	return w
+}
+
+// AlternativeTrigger get the named object as AlternativeTrigger.
+func (b *BuildHelp) AlternativeTrigger(name string) (w *gtk.AlternativeTrigger) {
+	b.getO("AlternativeTrigger", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.AlternativeTrigger); return })
+	return w
+}
+
+// AnyFilter get the named object as AnyFilter.
+func (b *BuildHelp) AnyFilter(name string) (w *gtk.AnyFilter) {
+	b.getO("AnyFilter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.AnyFilter); return })
+	return w
+}
+
+// AppChooserButton get the named object as AppChooserButton.
+func (b *BuildHelp) AppChooserButton(name string) (w *gtk.AppChooserButton) {
+	b.getO("AppChooserButton", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.AppChooserButton); return })
+	return w
+}
+
+// AppChooserDialog get the named object as AppChooserDialog.
+func (b *BuildHelp) AppChooserDialog(name string) (w *gtk.AppChooserDialog) {
+	b.getO("AppChooserDialog", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.AppChooserDialog); return })
+	return w
+}
+
+// AppChooserWidget get the named object as AppChooserWidget.
+func (b *BuildHelp) AppChooserWidget(name string) (w *gtk.AppChooserWidget) {
+	b.getO("AppChooserWidget", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.AppChooserWidget); return })
+	return w
+}
+
+// Application get the named object as Application.
+func (b *BuildHelp) Application(name string) (w *gtk.Application) {
+	b.getO("Application", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Application); return })
+	return w
+}
+
+// ApplicationWindow get the named object as ApplicationWindow.
+func (b *BuildHelp) ApplicationWindow(name string) (w *gtk.ApplicationWindow) {
+	b.getO("ApplicationWindow", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ApplicationWindow); return })
+	return w
+}
+
+// AspectFrame get the named object as AspectFrame.
+func (b *BuildHelp) AspectFrame(name string) (w *gtk.AspectFrame) {
+	b.getO("AspectFrame", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.AspectFrame); return })
+	return w
+}
+
+// Assistant get the named object as Assistant.
+func (b *BuildHelp) Assistant(name string) (w *gtk.Assistant) {
+	b.getO("Assistant", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Assistant); return })
+	return w
+}
+
+// AssistantPage get the named object as AssistantPage.
+func (b *BuildHelp) AssistantPage(name string) (w *gtk.AssistantPage) {
+	b.getO("AssistantPage", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.AssistantPage); return })
+	return w
+}
+
+// BinLayout get the named object as BinLayout.
+func (b *BuildHelp) BinLayout(name string) (w *gtk.BinLayout) {
+	b.getO("BinLayout", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.BinLayout); return })
+	return w
+}
+
+// BookmarkList get the named object as BookmarkList.
+func (b *BuildHelp) BookmarkList(name string) (w *gtk.BookmarkList) {
+	b.getO("BookmarkList", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.BookmarkList); return })
+	return w
+}
+
+// BoolFilter get the named object as BoolFilter.
+func (b *BuildHelp) BoolFilter(name string) (w *gtk.BoolFilter) {
+	b.getO("BoolFilter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.BoolFilter); return })
+	return w
+}
+
+// Box get the named object as Box.
+func (b *BuildHelp) Box(name string) (w *gtk.Box) {
+	b.getO("Box", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Box); return })
+	return w
+}
+
+// BoxLayout get the named object as BoxLayout.
+func (b *BuildHelp) BoxLayout(name string) (w *gtk.BoxLayout) {
+	b.getO("BoxLayout", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.BoxLayout); return })
+	return w
+}
+
+// BuilderCScope get the named object as BuilderCScope.
+func (b *BuildHelp) BuilderCScope(name string) (w *gtk.BuilderCScope) {
+	b.getO("BuilderCScope", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.BuilderCScope); return })
+	return w
+}
+
+// BuilderListItemFactory get the named object as BuilderListItemFactory.
+func (b *BuildHelp) BuilderListItemFactory(name string) (w *gtk.BuilderListItemFactory) {
+	b.getO("BuilderListItemFactory", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.BuilderListItemFactory); return })
+	return w
+}
+
+// Button get the named object as Button.
+func (b *BuildHelp) Button(name string) (w *gtk.Button) {
+	b.getO("Button", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Button); return })
+	return w
+}
+
+// CSSProvider get the named object as CSSProvider.
+func (b *BuildHelp) CSSProvider(name string) (w *gtk.CSSProvider) {
+	b.getO("CSSProvider", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CSSProvider); return })
+	return w
+}
+
+// Calendar get the named object as Calendar.
+func (b *BuildHelp) Calendar(name string) (w *gtk.Calendar) {
+	b.getO("Calendar", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Calendar); return })
+	return w
+}
+
+// CallbackAction get the named object as CallbackAction.
+func (b *BuildHelp) CallbackAction(name string) (w *gtk.CallbackAction) {
+	b.getO("CallbackAction", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CallbackAction); return })
+	return w
+}
+
+// CellAreaBox get the named object as CellAreaBox.
+func (b *BuildHelp) CellAreaBox(name string) (w *gtk.CellAreaBox) {
+	b.getO("CellAreaBox", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CellAreaBox); return })
+	return w
+}
+
+// CellAreaContext get the named object as CellAreaContext.
+func (b *BuildHelp) CellAreaContext(name string) (w *gtk.CellAreaContext) {
+	b.getO("CellAreaContext", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CellAreaContext); return })
+	return w
+}
+
+// CellRendererAccel get the named object as CellRendererAccel.
+func (b *BuildHelp) CellRendererAccel(name string) (w *gtk.CellRendererAccel) {
+	b.getO("CellRendererAccel", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CellRendererAccel); return })
+	return w
+}
+
+// CellRendererCombo get the named object as CellRendererCombo.
+func (b *BuildHelp) CellRendererCombo(name string) (w *gtk.CellRendererCombo) {
+	b.getO("CellRendererCombo", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CellRendererCombo); return })
+	return w
+}
+
+// CellRendererPixbuf get the named object as CellRendererPixbuf.
+func (b *BuildHelp) CellRendererPixbuf(name string) (w *gtk.CellRendererPixbuf) {
+	b.getO("CellRendererPixbuf", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CellRendererPixbuf); return })
+	return w
+}
+
+// CellRendererProgress get the named object as CellRendererProgress.
+func (b *BuildHelp) CellRendererProgress(name string) (w *gtk.CellRendererProgress) {
+	b.getO("CellRendererProgress", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CellRendererProgress); return })
+	return w
+}
+
+// CellRendererSpin get the named object as CellRendererSpin.
+func (b *BuildHelp) CellRendererSpin(name string) (w *gtk.CellRendererSpin) {
+	b.getO("CellRendererSpin", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CellRendererSpin); return })
+	return w
+}
+
+// CellRendererSpinner get the named object as CellRendererSpinner.
+func (b *BuildHelp) CellRendererSpinner(name string) (w *gtk.CellRendererSpinner) {
+	b.getO("CellRendererSpinner", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CellRendererSpinner); return })
+	return w
+}
+
+// CellRendererText get the named object as CellRendererText.
+func (b *BuildHelp) CellRendererText(name string) (w *gtk.CellRendererText) {
+	b.getO("CellRendererText", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CellRendererText); return })
+	return w
+}
+
+// CellRendererToggle get the named object as CellRendererToggle.
+func (b *BuildHelp) CellRendererToggle(name string) (w *gtk.CellRendererToggle) {
+	b.getO("CellRendererToggle", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CellRendererToggle); return })
+	return w
+}
+
+// CellView get the named object as CellView.
+func (b *BuildHelp) CellView(name string) (w *gtk.CellView) {
+	b.getO("CellView", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CellView); return })
+	return w
+}
+
+// CenterBox get the named object as CenterBox.
+func (b *BuildHelp) CenterBox(name string) (w *gtk.CenterBox) {
+	b.getO("CenterBox", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CenterBox); return })
+	return w
+}
+
+// CenterLayout get the named object as CenterLayout.
+func (b *BuildHelp) CenterLayout(name string) (w *gtk.CenterLayout) {
+	b.getO("CenterLayout", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CenterLayout); return })
+	return w
+}
+
+// CheckButton get the named object as CheckButton.
+func (b *BuildHelp) CheckButton(name string) (w *gtk.CheckButton) {
+	b.getO("CheckButton", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CheckButton); return })
+	return w
+}
+
+// ColorButton get the named object as ColorButton.
+func (b *BuildHelp) ColorButton(name string) (w *gtk.ColorButton) {
+	b.getO("ColorButton", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ColorButton); return })
+	return w
+}
+
+// ColorChooserDialog get the named object as ColorChooserDialog.
+func (b *BuildHelp) ColorChooserDialog(name string) (w *gtk.ColorChooserDialog) {
+	b.getO("ColorChooserDialog", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ColorChooserDialog); return })
+	return w
+}
+
+// ColorChooserWidget get the named object as ColorChooserWidget.
+func (b *BuildHelp) ColorChooserWidget(name string) (w *gtk.ColorChooserWidget) {
+	b.getO("ColorChooserWidget", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ColorChooserWidget); return })
+	return w
+}
+
+// ColumnView get the named object as ColumnView.
+func (b *BuildHelp) ColumnView(name string) (w *gtk.ColumnView) {
+	b.getO("ColumnView", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ColumnView); return })
+	return w
+}
+
+// ColumnViewColumn get the named object as ColumnViewColumn.
+func (b *BuildHelp) ColumnViewColumn(name string) (w *gtk.ColumnViewColumn) {
+	b.getO("ColumnViewColumn", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ColumnViewColumn); return })
+	return w
+}
+
+// ComboBox get the named object as ComboBox.
+func (b *BuildHelp) ComboBox(name string) (w *gtk.ComboBox) {
+	b.getO("ComboBox", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ComboBox); return })
+	return w
+}
+
+// ComboBoxText get the named object as ComboBoxText.
+func (b *BuildHelp) ComboBoxText(name string) (w *gtk.ComboBoxText) {
+	b.getO("ComboBoxText", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ComboBoxText); return })
+	return w
+}
+
+// Constraint get the named object as Constraint.
+func (b *BuildHelp) Constraint(name string) (w *gtk.Constraint) {
+	b.getO("Constraint", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Constraint); return })
+	return w
+}
+
+// ConstraintGuide get the named object as ConstraintGuide.
+func (b *BuildHelp) ConstraintGuide(name string) (w *gtk.ConstraintGuide) {
+	b.getO("ConstraintGuide", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ConstraintGuide); return })
+	return w
+}
+
+// ConstraintLayout get the named object as ConstraintLayout.
+func (b *BuildHelp) ConstraintLayout(name string) (w *gtk.ConstraintLayout) {
+	b.getO("ConstraintLayout", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ConstraintLayout); return })
+	return w
+}
+
+// ConstraintLayoutChild get the named object as ConstraintLayoutChild.
+func (b *BuildHelp) ConstraintLayoutChild(name string) (w *gtk.ConstraintLayoutChild) {
+	b.getO("ConstraintLayoutChild", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ConstraintLayoutChild); return })
+	return w
+}
+
+// CustomFilter get the named object as CustomFilter.
+func (b *BuildHelp) CustomFilter(name string) (w *gtk.CustomFilter) {
+	b.getO("CustomFilter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CustomFilter); return })
+	return w
+}
+
+// CustomLayout get the named object as CustomLayout.
+func (b *BuildHelp) CustomLayout(name string) (w *gtk.CustomLayout) {
+	b.getO("CustomLayout", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CustomLayout); return })
+	return w
+}
+
+// CustomSorter get the named object as CustomSorter.
+func (b *BuildHelp) CustomSorter(name string) (w *gtk.CustomSorter) {
+	b.getO("CustomSorter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CustomSorter); return })
+	return w
+}
+
+// Dialog get the named object as Dialog.
+func (b *BuildHelp) Dialog(name string) (w *gtk.Dialog) {
+	b.getO("Dialog", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Dialog); return })
+	return w
+}
+
+// DirectoryList get the named object as DirectoryList.
+func (b *BuildHelp) DirectoryList(name string) (w *gtk.DirectoryList) {
+	b.getO("DirectoryList", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.DirectoryList); return })
+	return w
+}
+
+// DragIcon get the named object as DragIcon.
+func (b *BuildHelp) DragIcon(name string) (w *gtk.DragIcon) {
+	b.getO("DragIcon", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.DragIcon); return })
+	return w
+}
+
+// DragSource get the named object as DragSource.
+func (b *BuildHelp) DragSource(name string) (w *gtk.DragSource) {
+	b.getO("DragSource", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.DragSource); return })
+	return w
+}
+
+// DrawingArea get the named object as DrawingArea.
+func (b *BuildHelp) DrawingArea(name string) (w *gtk.DrawingArea) {
+	b.getO("DrawingArea", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.DrawingArea); return })
+	return w
+}
+
+// DropControllerMotion get the named object as DropControllerMotion.
+func (b *BuildHelp) DropControllerMotion(name string) (w *gtk.DropControllerMotion) {
+	b.getO("DropControllerMotion", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.DropControllerMotion); return })
+	return w
+}
+
+// DropDown get the named object as DropDown.
+func (b *BuildHelp) DropDown(name string) (w *gtk.DropDown) {
+	b.getO("DropDown", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.DropDown); return })
+	return w
+}
+
+// DropTarget get the named object as DropTarget.
+func (b *BuildHelp) DropTarget(name string) (w *gtk.DropTarget) {
+	b.getO("DropTarget", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.DropTarget); return })
+	return w
+}
+
+// DropTargetAsync get the named object as DropTargetAsync.
+func (b *BuildHelp) DropTargetAsync(name string) (w *gtk.DropTargetAsync) {
+	b.getO("DropTargetAsync", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.DropTargetAsync); return })
+	return w
+}
+
+// EditableLabel get the named object as EditableLabel.
+func (b *BuildHelp) EditableLabel(name string) (w *gtk.EditableLabel) {
+	b.getO("EditableLabel", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.EditableLabel); return })
+	return w
+}
+
+// EmojiChooser get the named object as EmojiChooser.
+func (b *BuildHelp) EmojiChooser(name string) (w *gtk.EmojiChooser) {
+	b.getO("EmojiChooser", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.EmojiChooser); return })
+	return w
+}
+
+// Entry get the named object as Entry.
+func (b *BuildHelp) Entry(name string) (w *gtk.Entry) {
+	b.getO("Entry", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Entry); return })
+	return w
+}
+
+// EntryBuffer get the named object as EntryBuffer.
+func (b *BuildHelp) EntryBuffer(name string) (w *gtk.EntryBuffer) {
+	b.getO("EntryBuffer", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.EntryBuffer); return })
+	return w
+}
+
+// EntryCompletion get the named object as EntryCompletion.
+func (b *BuildHelp) EntryCompletion(name string) (w *gtk.EntryCompletion) {
+	b.getO("EntryCompletion", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.EntryCompletion); return })
+	return w
+}
+
+// EventControllerFocus get the named object as EventControllerFocus.
+func (b *BuildHelp) EventControllerFocus(name string) (w *gtk.EventControllerFocus) {
+	b.getO("EventControllerFocus", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.EventControllerFocus); return })
+	return w
+}
+
+// EventControllerKey get the named object as EventControllerKey.
+func (b *BuildHelp) EventControllerKey(name string) (w *gtk.EventControllerKey) {
+	b.getO("EventControllerKey", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.EventControllerKey); return })
+	return w
+}
+
+// EventControllerLegacy get the named object as EventControllerLegacy.
+func (b *BuildHelp) EventControllerLegacy(name string) (w *gtk.EventControllerLegacy) {
+	b.getO("EventControllerLegacy", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.EventControllerLegacy); return })
+	return w
+}
+
+// EventControllerMotion get the named object as EventControllerMotion.
+func (b *BuildHelp) EventControllerMotion(name string) (w *gtk.EventControllerMotion) {
+	b.getO("EventControllerMotion", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.EventControllerMotion); return })
+	return w
+}
+
+// EventControllerScroll get the named object as EventControllerScroll.
+func (b *BuildHelp) EventControllerScroll(name string) (w *gtk.EventControllerScroll) {
+	b.getO("EventControllerScroll", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.EventControllerScroll); return })
+	return w
+}
+
+// EveryFilter get the named object as EveryFilter.
+func (b *BuildHelp) EveryFilter(name string) (w *gtk.EveryFilter) {
+	b.getO("EveryFilter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.EveryFilter); return })
+	return w
+}
+
+// Expander get the named object as Expander.
+func (b *BuildHelp) Expander(name string) (w *gtk.Expander) {
+	b.getO("Expander", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Expander); return })
+	return w
+}
+
+// FileChooserDialog get the named object as FileChooserDialog.
+func (b *BuildHelp) FileChooserDialog(name string) (w *gtk.FileChooserDialog) {
+	b.getO("FileChooserDialog", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.FileChooserDialog); return })
+	return w
+}
+
+// FileChooserNative get the named object as FileChooserNative.
+func (b *BuildHelp) FileChooserNative(name string) (w *gtk.FileChooserNative) {
+	b.getO("FileChooserNative", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.FileChooserNative); return })
+	return w
+}
+
+// FileChooserWidget get the named object as FileChooserWidget.
+func (b *BuildHelp) FileChooserWidget(name string) (w *gtk.FileChooserWidget) {
+	b.getO("FileChooserWidget", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.FileChooserWidget); return })
+	return w
+}
+
+// FileFilter get the named object as FileFilter.
+func (b *BuildHelp) FileFilter(name string) (w *gtk.FileFilter) {
+	b.getO("FileFilter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.FileFilter); return })
+	return w
+}
+
+// Filter get the named object as Filter.
+func (b *BuildHelp) Filter(name string) (w *gtk.Filter) {
+	b.getO("Filter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Filter); return })
+	return w
+}
+
+// FilterListModel get the named object as FilterListModel.
+func (b *BuildHelp) FilterListModel(name string) (w *gtk.FilterListModel) {
+	b.getO("FilterListModel", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.FilterListModel); return })
+	return w
+}
+
+// Fixed get the named object as Fixed.
+func (b *BuildHelp) Fixed(name string) (w *gtk.Fixed) {
+	b.getO("Fixed", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Fixed); return })
+	return w
+}
+
+// FixedLayout get the named object as FixedLayout.
+func (b *BuildHelp) FixedLayout(name string) (w *gtk.FixedLayout) {
+	b.getO("FixedLayout", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.FixedLayout); return })
+	return w
+}
+
+// FixedLayoutChild get the named object as FixedLayoutChild.
+func (b *BuildHelp) FixedLayoutChild(name string) (w *gtk.FixedLayoutChild) {
+	b.getO("FixedLayoutChild", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.FixedLayoutChild); return })
+	return w
+}
+
+// FlattenListModel get the named object as FlattenListModel.
+func (b *BuildHelp) FlattenListModel(name string) (w *gtk.FlattenListModel) {
+	b.getO("FlattenListModel", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.FlattenListModel); return })
+	return w
+}
+
+// FlowBox get the named object as FlowBox.
+func (b *BuildHelp) FlowBox(name string) (w *gtk.FlowBox) {
+	b.getO("FlowBox", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.FlowBox); return })
+	return w
+}
+
+// FlowBoxChild get the named object as FlowBoxChild.
+func (b *BuildHelp) FlowBoxChild(name string) (w *gtk.FlowBoxChild) {
+	b.getO("FlowBoxChild", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.FlowBoxChild); return })
+	return w
+}
+
+// FontButton get the named object as FontButton.
+func (b *BuildHelp) FontButton(name string) (w *gtk.FontButton) {
+	b.getO("FontButton", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.FontButton); return })
+	return w
+}
+
+// FontChooserDialog get the named object as FontChooserDialog.
+func (b *BuildHelp) FontChooserDialog(name string) (w *gtk.FontChooserDialog) {
+	b.getO("FontChooserDialog", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.FontChooserDialog); return })
+	return w
+}
+
+// FontChooserWidget get the named object as FontChooserWidget.
+func (b *BuildHelp) FontChooserWidget(name string) (w *gtk.FontChooserWidget) {
+	b.getO("FontChooserWidget", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.FontChooserWidget); return })
+	return w
+}
+
+// Frame get the named object as Frame.
+func (b *BuildHelp) Frame(name string) (w *gtk.Frame) {
+	b.getO("Frame", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Frame); return })
+	return w
+}
+
+// GLArea get the named object as GLArea.
+func (b *BuildHelp) GLArea(name string) (w *gtk.GLArea) {
+	b.getO("GLArea", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.GLArea); return })
+	return w
+}
+
+// GestureClick get the named object as GestureClick.
+func (b *BuildHelp) GestureClick(name string) (w *gtk.GestureClick) {
+	b.getO("GestureClick", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.GestureClick); return })
+	return w
+}
+
+// GestureDrag get the named object as GestureDrag.
+func (b *BuildHelp) GestureDrag(name string) (w *gtk.GestureDrag) {
+	b.getO("GestureDrag", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.GestureDrag); return })
+	return w
+}
+
+// GestureLongPress get the named object as GestureLongPress.
+func (b *BuildHelp) GestureLongPress(name string) (w *gtk.GestureLongPress) {
+	b.getO("GestureLongPress", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.GestureLongPress); return })
+	return w
+}
+
+// GesturePan get the named object as GesturePan.
+func (b *BuildHelp) GesturePan(name string) (w *gtk.GesturePan) {
+	b.getO("GesturePan", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.GesturePan); return })
+	return w
+}
+
+// GestureRotate get the named object as GestureRotate.
+func (b *BuildHelp) GestureRotate(name string) (w *gtk.GestureRotate) {
+	b.getO("GestureRotate", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.GestureRotate); return })
+	return w
+}
+
+// GestureSingle get the named object as GestureSingle.
+func (b *BuildHelp) GestureSingle(name string) (w *gtk.GestureSingle) {
+	b.getO("GestureSingle", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.GestureSingle); return })
+	return w
+}
+
+// GestureStylus get the named object as GestureStylus.
+func (b *BuildHelp) GestureStylus(name string) (w *gtk.GestureStylus) {
+	b.getO("GestureStylus", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.GestureStylus); return })
+	return w
+}
+
+// GestureSwipe get the named object as GestureSwipe.
+func (b *BuildHelp) GestureSwipe(name string) (w *gtk.GestureSwipe) {
+	b.getO("GestureSwipe", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.GestureSwipe); return })
+	return w
+}
+
+// GestureZoom get the named object as GestureZoom.
+func (b *BuildHelp) GestureZoom(name string) (w *gtk.GestureZoom) {
+	b.getO("GestureZoom", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.GestureZoom); return })
+	return w
+}
+
+// Grid get the named object as Grid.
+func (b *BuildHelp) Grid(name string) (w *gtk.Grid) {
+	b.getO("Grid", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Grid); return })
+	return w
+}
+
+// GridLayout get the named object as GridLayout.
+func (b *BuildHelp) GridLayout(name string) (w *gtk.GridLayout) {
+	b.getO("GridLayout", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.GridLayout); return })
+	return w
+}
+
+// GridLayoutChild get the named object as GridLayoutChild.
+func (b *BuildHelp) GridLayoutChild(name string) (w *gtk.GridLayoutChild) {
+	b.getO("GridLayoutChild", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.GridLayoutChild); return })
+	return w
+}
+
+// GridView get the named object as GridView.
+func (b *BuildHelp) GridView(name string) (w *gtk.GridView) {
+	b.getO("GridView", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.GridView); return })
+	return w
+}
+
+// HeaderBar get the named object as HeaderBar.
+func (b *BuildHelp) HeaderBar(name string) (w *gtk.HeaderBar) {
+	b.getO("HeaderBar", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.HeaderBar); return })
+	return w
+}
+
+// IMContextSimple get the named object as IMContextSimple.
+func (b *BuildHelp) IMContextSimple(name string) (w *gtk.IMContextSimple) {
+	b.getO("IMContextSimple", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.IMContextSimple); return })
+	return w
+}
+
+// IMMulticontext get the named object as IMMulticontext.
+func (b *BuildHelp) IMMulticontext(name string) (w *gtk.IMMulticontext) {
+	b.getO("IMMulticontext", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.IMMulticontext); return })
+	return w
+}
+
+// IconPaintable get the named object as IconPaintable.
+func (b *BuildHelp) IconPaintable(name string) (w *gtk.IconPaintable) {
+	b.getO("IconPaintable", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.IconPaintable); return })
+	return w
+}
+
+// IconTheme get the named object as IconTheme.
+func (b *BuildHelp) IconTheme(name string) (w *gtk.IconTheme) {
+	b.getO("IconTheme", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.IconTheme); return })
+	return w
+}
+
+// IconView get the named object as IconView.
+func (b *BuildHelp) IconView(name string) (w *gtk.IconView) {
+	b.getO("IconView", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.IconView); return })
+	return w
+}
+
+// Image get the named object as Image.
+func (b *BuildHelp) Image(name string) (w *gtk.Image) {
+	b.getO("Image", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Image); return })
+	return w
+}
+
+// InfoBar get the named object as InfoBar.
+func (b *BuildHelp) InfoBar(name string) (w *gtk.InfoBar) {
+	b.getO("InfoBar", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.InfoBar); return })
+	return w
+}
+
+// KeyvalTrigger get the named object as KeyvalTrigger.
+func (b *BuildHelp) KeyvalTrigger(name string) (w *gtk.KeyvalTrigger) {
+	b.getO("KeyvalTrigger", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.KeyvalTrigger); return })
+	return w
+}
+
+// Label get the named object as Label.
+func (b *BuildHelp) Label(name string) (w *gtk.Label) {
+	b.getO("Label", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Label); return })
+	return w
+}
+
+// LevelBar get the named object as LevelBar.
+func (b *BuildHelp) LevelBar(name string) (w *gtk.LevelBar) {
+	b.getO("LevelBar", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.LevelBar); return })
+	return w
+}
+
+// LinkButton get the named object as LinkButton.
+func (b *BuildHelp) LinkButton(name string) (w *gtk.LinkButton) {
+	b.getO("LinkButton", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.LinkButton); return })
+	return w
+}
+
+// ListBox get the named object as ListBox.
+func (b *BuildHelp) ListBox(name string) (w *gtk.ListBox) {
+	b.getO("ListBox", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ListBox); return })
+	return w
+}
+
+// ListBoxRow get the named object as ListBoxRow.
+func (b *BuildHelp) ListBoxRow(name string) (w *gtk.ListBoxRow) {
+	b.getO("ListBoxRow", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ListBoxRow); return })
+	return w
+}
+
+// ListItem get the named object as ListItem.
+func (b *BuildHelp) ListItem(name string) (w *gtk.ListItem) {
+	b.getO("ListItem", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ListItem); return })
+	return w
+}
+
+// ListItemFactory get the named object as ListItemFactory.
+func (b *BuildHelp) ListItemFactory(name string) (w *gtk.ListItemFactory) {
+	b.getO("ListItemFactory", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ListItemFactory); return })
+	return w
+}
+
+// ListStore get the named object as ListStore.
+func (b *BuildHelp) ListStore(name string) (w *gtk.ListStore) {
+	b.getO("ListStore", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ListStore); return })
+	return w
+}
+
+// ListView get the named object as ListView.
+func (b *BuildHelp) ListView(name string) (w *gtk.ListView) {
+	b.getO("ListView", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ListView); return })
+	return w
+}
+
+// LockButton get the named object as LockButton.
+func (b *BuildHelp) LockButton(name string) (w *gtk.LockButton) {
+	b.getO("LockButton", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.LockButton); return })
+	return w
+}
+
+// MapListModel get the named object as MapListModel.
+func (b *BuildHelp) MapListModel(name string) (w *gtk.MapListModel) {
+	b.getO("MapListModel", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.MapListModel); return })
+	return w
+}
+
+// MediaControls get the named object as MediaControls.
+func (b *BuildHelp) MediaControls(name string) (w *gtk.MediaControls) {
+	b.getO("MediaControls", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.MediaControls); return })
+	return w
+}
+
+// MenuButton get the named object as MenuButton.
+func (b *BuildHelp) MenuButton(name string) (w *gtk.MenuButton) {
+	b.getO("MenuButton", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.MenuButton); return })
+	return w
+}
+
+// MessageDialog get the named object as MessageDialog.
+func (b *BuildHelp) MessageDialog(name string) (w *gtk.MessageDialog) {
+	b.getO("MessageDialog", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.MessageDialog); return })
+	return w
+}
+
+// MnemonicAction get the named object as MnemonicAction.
+func (b *BuildHelp) MnemonicAction(name string) (w *gtk.MnemonicAction) {
+	b.getO("MnemonicAction", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.MnemonicAction); return })
+	return w
+}
+
+// MnemonicTrigger get the named object as MnemonicTrigger.
+func (b *BuildHelp) MnemonicTrigger(name string) (w *gtk.MnemonicTrigger) {
+	b.getO("MnemonicTrigger", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.MnemonicTrigger); return })
+	return w
+}
+
+// MountOperation get the named object as MountOperation.
+func (b *BuildHelp) MountOperation(name string) (w *gtk.MountOperation) {
+	b.getO("MountOperation", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.MountOperation); return })
+	return w
+}
+
+// MultiSelection get the named object as MultiSelection.
+func (b *BuildHelp) MultiSelection(name string) (w *gtk.MultiSelection) {
+	b.getO("MultiSelection", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.MultiSelection); return })
+	return w
+}
+
+// MultiSorter get the named object as MultiSorter.
+func (b *BuildHelp) MultiSorter(name string) (w *gtk.MultiSorter) {
+	b.getO("MultiSorter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.MultiSorter); return })
+	return w
+}
+
+// NamedAction get the named object as NamedAction.
+func (b *BuildHelp) NamedAction(name string) (w *gtk.NamedAction) {
+	b.getO("NamedAction", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.NamedAction); return })
+	return w
+}
+
+// NeverTrigger get the named object as NeverTrigger.
+func (b *BuildHelp) NeverTrigger(name string) (w *gtk.NeverTrigger) {
+	b.getO("NeverTrigger", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.NeverTrigger); return })
+	return w
+}
+
+// NoSelection get the named object as NoSelection.
+func (b *BuildHelp) NoSelection(name string) (w *gtk.NoSelection) {
+	b.getO("NoSelection", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.NoSelection); return })
+	return w
+}
+
+// Notebook get the named object as Notebook.
+func (b *BuildHelp) Notebook(name string) (w *gtk.Notebook) {
+	b.getO("Notebook", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Notebook); return })
+	return w
+}
+
+// NotebookPage get the named object as NotebookPage.
+func (b *BuildHelp) NotebookPage(name string) (w *gtk.NotebookPage) {
+	b.getO("NotebookPage", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.NotebookPage); return })
+	return w
+}
+
+// NothingAction get the named object as NothingAction.
+func (b *BuildHelp) NothingAction(name string) (w *gtk.NothingAction) {
+	b.getO("NothingAction", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.NothingAction); return })
+	return w
+}
+
+// NumericSorter get the named object as NumericSorter.
+func (b *BuildHelp) NumericSorter(name string) (w *gtk.NumericSorter) {
+	b.getO("NumericSorter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.NumericSorter); return })
+	return w
+}
+
+// Overlay get the named object as Overlay.
+func (b *BuildHelp) Overlay(name string) (w *gtk.Overlay) {
+	b.getO("Overlay", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Overlay); return })
+	return w
+}
+
+// OverlayLayout get the named object as OverlayLayout.
+func (b *BuildHelp) OverlayLayout(name string) (w *gtk.OverlayLayout) {
+	b.getO("OverlayLayout", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.OverlayLayout); return })
+	return w
+}
+
+// OverlayLayoutChild get the named object as OverlayLayoutChild.
+func (b *BuildHelp) OverlayLayoutChild(name string) (w *gtk.OverlayLayoutChild) {
+	b.getO("OverlayLayoutChild", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.OverlayLayoutChild); return })
+	return w
+}
+
+// PadController get the named object as PadController.
+func (b *BuildHelp) PadController(name string) (w *gtk.PadController) {
+	b.getO("PadController", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.PadController); return })
+	return w
+}
+
+// PageSetup get the named object as PageSetup.
+func (b *BuildHelp) PageSetup(name string) (w *gtk.PageSetup) {
+	b.getO("PageSetup", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.PageSetup); return })
+	return w
+}
+
+// Paned get the named object as Paned.
+func (b *BuildHelp) Paned(name string) (w *gtk.Paned) {
+	b.getO("Paned", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Paned); return })
+	return w
+}
+
+// PasswordEntry get the named object as PasswordEntry.
+func (b *BuildHelp) PasswordEntry(name string) (w *gtk.PasswordEntry) {
+	b.getO("PasswordEntry", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.PasswordEntry); return })
+	return w
+}
+
+// Picture get the named object as Picture.
+func (b *BuildHelp) Picture(name string) (w *gtk.Picture) {
+	b.getO("Picture", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Picture); return })
+	return w
+}
+
+// Popover get the named object as Popover.
+func (b *BuildHelp) Popover(name string) (w *gtk.Popover) {
+	b.getO("Popover", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Popover); return })
+	return w
+}
+
+// PopoverMenu get the named object as PopoverMenu.
+func (b *BuildHelp) PopoverMenu(name string) (w *gtk.PopoverMenu) {
+	b.getO("PopoverMenu", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.PopoverMenu); return })
+	return w
+}
+
+// PopoverMenuBar get the named object as PopoverMenuBar.
+func (b *BuildHelp) PopoverMenuBar(name string) (w *gtk.PopoverMenuBar) {
+	b.getO("PopoverMenuBar", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.PopoverMenuBar); return })
+	return w
+}
+
+// PrintContext get the named object as PrintContext.
+func (b *BuildHelp) PrintContext(name string) (w *gtk.PrintContext) {
+	b.getO("PrintContext", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.PrintContext); return })
+	return w
+}
+
+// PrintOperation get the named object as PrintOperation.
+func (b *BuildHelp) PrintOperation(name string) (w *gtk.PrintOperation) {
+	b.getO("PrintOperation", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.PrintOperation); return })
+	return w
+}
+
+// PrintSettings get the named object as PrintSettings.
+func (b *BuildHelp) PrintSettings(name string) (w *gtk.PrintSettings) {
+	b.getO("PrintSettings", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.PrintSettings); return })
+	return w
+}
+
+// ProgressBar get the named object as ProgressBar.
+func (b *BuildHelp) ProgressBar(name string) (w *gtk.ProgressBar) {
+	b.getO("ProgressBar", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ProgressBar); return })
+	return w
+}
+
+// Range get the named object as Range.
+func (b *BuildHelp) Range(name string) (w *gtk.Range) {
+	b.getO("Range", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Range); return })
+	return w
+}
+
+// RecentManager get the named object as RecentManager.
+func (b *BuildHelp) RecentManager(name string) (w *gtk.RecentManager) {
+	b.getO("RecentManager", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.RecentManager); return })
+	return w
+}
+
+// Revealer get the named object as Revealer.
+func (b *BuildHelp) Revealer(name string) (w *gtk.Revealer) {
+	b.getO("Revealer", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Revealer); return })
+	return w
+}
+
+// Scale get the named object as Scale.
+func (b *BuildHelp) Scale(name string) (w *gtk.Scale) {
+	b.getO("Scale", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Scale); return })
+	return w
+}
+
+// ScaleButton get the named object as ScaleButton.
+func (b *BuildHelp) ScaleButton(name string) (w *gtk.ScaleButton) {
+	b.getO("ScaleButton", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ScaleButton); return })
+	return w
+}
+
+// Scrollbar get the named object as Scrollbar.
+func (b *BuildHelp) Scrollbar(name string) (w *gtk.Scrollbar) {
+	b.getO("Scrollbar", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Scrollbar); return })
+	return w
+}
+
+// ScrolledWindow get the named object as ScrolledWindow.
+func (b *BuildHelp) ScrolledWindow(name string) (w *gtk.ScrolledWindow) {
+	b.getO("ScrolledWindow", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ScrolledWindow); return })
+	return w
+}
+
+// SearchBar get the named object as SearchBar.
+func (b *BuildHelp) SearchBar(name string) (w *gtk.SearchBar) {
+	b.getO("SearchBar", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.SearchBar); return })
+	return w
+}
+
+// SearchEntry get the named object as SearchEntry.
+func (b *BuildHelp) SearchEntry(name string) (w *gtk.SearchEntry) {
+	b.getO("SearchEntry", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.SearchEntry); return })
+	return w
+}
+
+// SelectionFilterModel get the named object as SelectionFilterModel.
+func (b *BuildHelp) SelectionFilterModel(name string) (w *gtk.SelectionFilterModel) {
+	b.getO("SelectionFilterModel", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.SelectionFilterModel); return })
+	return w
+}
+
+// Separator get the named object as Separator.
+func (b *BuildHelp) Separator(name string) (w *gtk.Separator) {
+	b.getO("Separator", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Separator); return })
+	return w
+}
+
+// Settings get the named object as Settings.
+func (b *BuildHelp) Settings(name string) (w *gtk.Settings) {
+	b.getO("Settings", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Settings); return })
+	return w
+}
+
+// Shortcut get the named object as Shortcut.
+func (b *BuildHelp) Shortcut(name string) (w *gtk.Shortcut) {
+	b.getO("Shortcut", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Shortcut); return })
+	return w
+}
+
+// ShortcutController get the named object as ShortcutController.
+func (b *BuildHelp) ShortcutController(name string) (w *gtk.ShortcutController) {
+	b.getO("ShortcutController", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ShortcutController); return })
+	return w
+}
+
+// ShortcutLabel get the named object as ShortcutLabel.
+func (b *BuildHelp) ShortcutLabel(name string) (w *gtk.ShortcutLabel) {
+	b.getO("ShortcutLabel", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ShortcutLabel); return })
+	return w
+}
+
+// ShortcutsGroup get the named object as ShortcutsGroup.
+func (b *BuildHelp) ShortcutsGroup(name string) (w *gtk.ShortcutsGroup) {
+	b.getO("ShortcutsGroup", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ShortcutsGroup); return })
+	return w
+}
+
+// ShortcutsSection get the named object as ShortcutsSection.
+func (b *BuildHelp) ShortcutsSection(name string) (w *gtk.ShortcutsSection) {
+	b.getO("ShortcutsSection", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ShortcutsSection); return })
+	return w
+}
+
+// ShortcutsShortcut get the named object as ShortcutsShortcut.
+func (b *BuildHelp) ShortcutsShortcut(name string) (w *gtk.ShortcutsShortcut) {
+	b.getO("ShortcutsShortcut", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ShortcutsShortcut); return })
+	return w
+}
+
+// ShortcutsWindow get the named object as ShortcutsWindow.
+func (b *BuildHelp) ShortcutsWindow(name string) (w *gtk.ShortcutsWindow) {
+	b.getO("ShortcutsWindow", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ShortcutsWindow); return })
+	return w
+}
+
+// SignalAction get the named object as SignalAction.
+func (b *BuildHelp) SignalAction(name string) (w *gtk.SignalAction) {
+	b.getO("SignalAction", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.SignalAction); return })
+	return w
+}
+
+// SignalListItemFactory get the named object as SignalListItemFactory.
+func (b *BuildHelp) SignalListItemFactory(name string) (w *gtk.SignalListItemFactory) {
+	b.getO("SignalListItemFactory", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.SignalListItemFactory); return })
+	return w
+}
+
+// SingleSelection get the named object as SingleSelection.
+func (b *BuildHelp) SingleSelection(name string) (w *gtk.SingleSelection) {
+	b.getO("SingleSelection", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.SingleSelection); return })
+	return w
+}
+
+// SizeGroup get the named object as SizeGroup.
+func (b *BuildHelp) SizeGroup(name string) (w *gtk.SizeGroup) {
+	b.getO("SizeGroup", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.SizeGroup); return })
+	return w
+}
+
+// SliceListModel get the named object as SliceListModel.
+func (b *BuildHelp) SliceListModel(name string) (w *gtk.SliceListModel) {
+	b.getO("SliceListModel", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.SliceListModel); return })
+	return w
+}
+
+// Snapshot get the named object as Snapshot.
+func (b *BuildHelp) Snapshot(name string) (w *gtk.Snapshot) {
+	b.getO("Snapshot", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Snapshot); return })
+	return w
+}
+
+// SortListModel get the named object as SortListModel.
+func (b *BuildHelp) SortListModel(name string) (w *gtk.SortListModel) {
+	b.getO("SortListModel", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.SortListModel); return })
+	return w
+}
+
+// Sorter get the named object as Sorter.
+func (b *BuildHelp) Sorter(name string) (w *gtk.Sorter) {
+	b.getO("Sorter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Sorter); return })
+	return w
+}
+
+// SpinButton get the named object as SpinButton.
+func (b *BuildHelp) SpinButton(name string) (w *gtk.SpinButton) {
+	b.getO("SpinButton", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.SpinButton); return })
+	return w
+}
+
+// Spinner get the named object as Spinner.
+func (b *BuildHelp) Spinner(name string) (w *gtk.Spinner) {
+	b.getO("Spinner", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Spinner); return })
+	return w
+}
+
+// Stack get the named object as Stack.
+func (b *BuildHelp) Stack(name string) (w *gtk.Stack) {
+	b.getO("Stack", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Stack); return })
+	return w
+}
+
+// StackPage get the named object as StackPage.
+func (b *BuildHelp) StackPage(name string) (w *gtk.StackPage) {
+	b.getO("StackPage", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.StackPage); return })
+	return w
+}
+
+// StackSidebar get the named object as StackSidebar.
+func (b *BuildHelp) StackSidebar(name string) (w *gtk.StackSidebar) {
+	b.getO("StackSidebar", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.StackSidebar); return })
+	return w
+}
+
+// StackSwitcher get the named object as StackSwitcher.
+func (b *BuildHelp) StackSwitcher(name string) (w *gtk.StackSwitcher) {
+	b.getO("StackSwitcher", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.StackSwitcher); return })
+	return w
+}
+
+// Statusbar get the named object as Statusbar.
+func (b *BuildHelp) Statusbar(name string) (w *gtk.Statusbar) {
+	b.getO("Statusbar", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Statusbar); return })
+	return w
+}
+
+// StringFilter get the named object as StringFilter.
+func (b *BuildHelp) StringFilter(name string) (w *gtk.StringFilter) {
+	b.getO("StringFilter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.StringFilter); return })
+	return w
+}
+
+// StringSorter get the named object as StringSorter.
+func (b *BuildHelp) StringSorter(name string) (w *gtk.StringSorter) {
+	b.getO("StringSorter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.StringSorter); return })
+	return w
+}
+
+// StyleContext get the named object as StyleContext.
+func (b *BuildHelp) StyleContext(name string) (w *gtk.StyleContext) {
+	b.getO("StyleContext", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.StyleContext); return })
+	return w
+}
+
+// Switch get the named object as Switch.
+func (b *BuildHelp) Switch(name string) (w *gtk.Switch) {
+	b.getO("Switch", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Switch); return })
+	return w
+}
+
+// Text get the named object as Text.
+func (b *BuildHelp) Text(name string) (w *gtk.Text) {
+	b.getO("Text", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Text); return })
+	return w
+}
+
+// TextBuffer get the named object as TextBuffer.
+func (b *BuildHelp) TextBuffer(name string) (w *gtk.TextBuffer) {
+	b.getO("TextBuffer", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TextBuffer); return })
+	return w
+}
+
+// TextChildAnchor get the named object as TextChildAnchor.
+func (b *BuildHelp) TextChildAnchor(name string) (w *gtk.TextChildAnchor) {
+	b.getO("TextChildAnchor", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TextChildAnchor); return })
+	return w
+}
+
+// TextMark get the named object as TextMark.
+func (b *BuildHelp) TextMark(name string) (w *gtk.TextMark) {
+	b.getO("TextMark", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TextMark); return })
+	return w
+}
+
+// TextTag get the named object as TextTag.
+func (b *BuildHelp) TextTag(name string) (w *gtk.TextTag) {
+	b.getO("TextTag", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TextTag); return })
+	return w
+}
+
+// TextTagTable get the named object as TextTagTable.
+func (b *BuildHelp) TextTagTable(name string) (w *gtk.TextTagTable) {
+	b.getO("TextTagTable", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TextTagTable); return })
+	return w
+}
+
+// TextView get the named object as TextView.
+func (b *BuildHelp) TextView(name string) (w *gtk.TextView) {
+	b.getO("TextView", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TextView); return })
+	return w
+}
+
+// ToggleButton get the named object as ToggleButton.
+func (b *BuildHelp) ToggleButton(name string) (w *gtk.ToggleButton) {
+	b.getO("ToggleButton", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ToggleButton); return })
+	return w
+}
+
+// Tooltip get the named object as Tooltip.
+func (b *BuildHelp) Tooltip(name string) (w *gtk.Tooltip) {
+	b.getO("Tooltip", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Tooltip); return })
+	return w
+}
+
+// TreeExpander get the named object as TreeExpander.
+func (b *BuildHelp) TreeExpander(name string) (w *gtk.TreeExpander) {
+	b.getO("TreeExpander", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeExpander); return })
+	return w
+}
+
+// TreeListModel get the named object as TreeListModel.
+func (b *BuildHelp) TreeListModel(name string) (w *gtk.TreeListModel) {
+	b.getO("TreeListModel", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeListModel); return })
+	return w
+}
+
+// TreeListRow get the named object as TreeListRow.
+func (b *BuildHelp) TreeListRow(name string) (w *gtk.TreeListRow) {
+	b.getO("TreeListRow", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeListRow); return })
+	return w
+}
+
+// TreeListRowSorter get the named object as TreeListRowSorter.
+func (b *BuildHelp) TreeListRowSorter(name string) (w *gtk.TreeListRowSorter) {
+	b.getO("TreeListRowSorter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeListRowSorter); return })
+	return w
+}
+
+// TreeModelFilter get the named object as TreeModelFilter.
+func (b *BuildHelp) TreeModelFilter(name string) (w *gtk.TreeModelFilter) {
+	b.getO("TreeModelFilter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeModelFilter); return })
+	return w
+}
+
+// TreeModelSort get the named object as TreeModelSort.
+func (b *BuildHelp) TreeModelSort(name string) (w *gtk.TreeModelSort) {
+	b.getO("TreeModelSort", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeModelSort); return })
+	return w
+}
+
+// TreeSelection get the named object as TreeSelection.
+func (b *BuildHelp) TreeSelection(name string) (w *gtk.TreeSelection) {
+	b.getO("TreeSelection", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeSelection); return })
+	return w
+}
+
+// TreeStore get the named object as TreeStore.
+func (b *BuildHelp) TreeStore(name string) (w *gtk.TreeStore) {
+	b.getO("TreeStore", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeStore); return })
+	return w
+}
+
+// TreeView get the named object as TreeView.
+func (b *BuildHelp) TreeView(name string) (w *gtk.TreeView) {
+	b.getO("TreeView", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeView); return })
+	return w
+}
+
+// TreeViewColumn get the named object as TreeViewColumn.
+func (b *BuildHelp) TreeViewColumn(name string) (w *gtk.TreeViewColumn) {
+	b.getO("TreeViewColumn", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeViewColumn); return })
+	return w
+}
+
+// Video get the named object as Video.
+func (b *BuildHelp) Video(name string) (w *gtk.Video) {
+	b.getO("Video", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Video); return })
+	return w
+}
+
+// Viewport get the named object as Viewport.
+func (b *BuildHelp) Viewport(name string) (w *gtk.Viewport) {
+	b.getO("Viewport", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Viewport); return })
+	return w
+}
+
+// VolumeButton get the named object as VolumeButton.
+func (b *BuildHelp) VolumeButton(name string) (w *gtk.VolumeButton) {
+	b.getO("VolumeButton", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.VolumeButton); return })
+	return w
+}
+
+// WidgetPaintable get the named object as WidgetPaintable.
+func (b *BuildHelp) WidgetPaintable(name string) (w *gtk.WidgetPaintable) {
+	b.getO("WidgetPaintable", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.WidgetPaintable); return })
+	return w
+}
+
+// Window get the named object as Window.
+func (b *BuildHelp) Window(name string) (w *gtk.Window) {
+	b.getO("Window", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Window); return })
+	return w
+}
+
+// WindowControls get the named object as WindowControls.
+func (b *BuildHelp) WindowControls(name string) (w *gtk.WindowControls) {
+	b.getO("WindowControls", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.WindowControls); return })
+	return w
+}
+
+// WindowGroup get the named object as WindowGroup.
+func (b *BuildHelp) WindowGroup(name string) (w *gtk.WindowGroup) {
+	b.getO("WindowGroup", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.WindowGroup); return })
+	return w
+}
+
+// WindowHandle get the named object as WindowHandle.
+func (b *BuildHelp) WindowHandle(name string) (w *gtk.WindowHandle) {
+	b.getO("WindowHandle", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.WindowHandle); return })
+	return w
+}
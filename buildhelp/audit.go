@@ -0,0 +1,137 @@
+package buildhelp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gtkool4/grun"
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+// Audit formating.
+var (
+	FmtAuditUsed   = "%s: %s (%s) fetched as %s"                                  // Format: position, id, class, accessor
+	FmtAuditRef    = "%s: %s (%s) referenced"                                     // Format: position, id, class
+	FmtAuditUnused = "%s: %s (%s) unused"                                         // Format: position, id, class
+	FmtErrUnused   = "%s: builder object %s (%s) is never fetched nor referenced" // Format: position, id, class
+)
+
+//
+//-------------------------------------------------------------------[ AUDIT ]--
+
+// AuditEntry describes an object declared with an id in the interface.
+type AuditEntry struct {
+	ID         string
+	Class      string
+	Pos        uixml.Pos // Position in the source file.
+	Accessor   string    // Type used to fetch the object, like Button. Empty if never fetched.
+	Referenced bool      // Used by another element of the interface, like a model property.
+}
+
+// Used returns true if the object was fetched or is referenced in the interface.
+func (e AuditEntry) Used() bool { return e.Accessor != "" || e.Referenced }
+
+func (e AuditEntry) String() string {
+	switch {
+	case e.Accessor != "":
+		return fmt.Sprintf(FmtAuditUsed, e.Pos, e.ID, e.Class, e.Accessor)
+	case e.Referenced:
+		return fmt.Sprintf(FmtAuditRef, e.Pos, e.ID, e.Class)
+	}
+	return fmt.Sprintf(FmtAuditUnused, e.Pos, e.ID, e.Class)
+}
+
+// Audit lists the objects declared in the interface, in document order.
+type Audit []AuditEntry
+
+// Unused returns the objects never fetched nor referenced.
+func (a Audit) Unused() (list Audit) {
+	for _, entry := range a {
+		if !entry.Used() {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
+// String returns the report, one object per line.
+func (a Audit) String() string {
+	lines := make([]string, len(a))
+	for i, entry := range a {
+		lines[i] = entry.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// UnusedError is the warning of an object never fetched nor referenced, see
+// WarnUnused.
+type UnusedError struct{ AuditEntry }
+
+func (e *UnusedError) Error() string { return fmt.Sprintf(FmtErrUnused, e.Pos, e.ID, e.Class) }
+
+// Audit returns the objects declared with an id in the loaded interfaces, with
+// the type used to fetch each, by accessors, Bind or ConnectSignals (as
+// "signal").
+//
+// Ids generated for signals are not listed.
+func (b *BuildHelp) Audit() Audit {
+	list := make(Audit, len(b.objects))
+	for i, entry := range b.objects {
+		entry.Accessor = b.fetched[entry.ID]
+		list[i] = entry
+	}
+	return list
+}
+
+// WarnUnused appends an *UnusedError to the builder errors for each object never
+// fetched nor referenced so far. Call it when the interface is fully set up.
+//
+// The errors are returned for convenience.
+func (b *BuildHelp) WarnUnused() grun.Errors {
+	for _, entry := range b.Audit().Unused() {
+		b.errors.Append(&UnusedError{entry})
+	}
+	return b.errors
+}
+
+// auditObjects records the objects declared with an id in the interface.
+func (b *BuildHelp) auditObjects(root *uixml.Node) {
+	ids := root.IDs()
+	refs := map[string]bool{}
+	root.Walk(func(n, parent *uixml.Node) bool {
+		switch {
+		case n.Name == "widget": // Size group member.
+			refs[n.Attr("name")] = true
+
+		case !contains(ids, n.Text): // Like a label with the text of an id.
+
+		case n.Name == "property" && parent != nil && b.isObjectProperty(parent.Attr("class"), n.Attr("name")),
+			n.Name == "action-widget", n.Name == "lookup":
+			refs[n.Text] = true
+		}
+		return true
+	})
+
+	for _, n := range root.Objects() {
+		if id := n.Attr("id"); id != "" {
+			b.objects = append(b.objects, AuditEntry{ID: id, Class: n.Attr("class"), Pos: n.Pos, Referenced: refs[id]})
+		}
+	}
+}
+
+// isObjectProperty returns true if the property of the class holds an object.
+// The class type is registered first, as the interface is not loaded yet.
+func (b *BuildHelp) isObjectProperty(class, property string) bool {
+	b.TypeFromName(class)
+	return isObjectProperty(class, property)
+}
+
+// fetch records the type used to fetch the object.
+func (b *BuildHelp) fetch(id, typ string) {
+	if b.fetched == nil {
+		b.fetched = map[string]string{}
+	}
+	if b.fetched[id] == "" {
+		b.fetched[id] = typ
+	}
+}
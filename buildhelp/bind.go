@@ -0,0 +1,93 @@
+package buildhelp
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/gtkool4/grun"
+
+	externglib "github.com/diamondburned/gotk4/pkg/core/glib"
+)
+
+// TagName is the struct field tag used by Bind to find the object id.
+var TagName = "gtk"
+
+// FmtErrBind formats the error when Bind is not given a pointer to a struct.
+var FmtErrBind = "builder bind needs a pointer to a struct, not %T"
+
+//
+//--------------------------------------------------------------------[ BIND ]--
+
+// Bind fills a struct with builder objects, using the id set in the `gtk:"id"`
+// field tag. Fields without tag, or tagged "-", are ignored.
+//
+// Fields can be pointers like *gtk.Button, values like an embedded gtk.Box
+// main container, interfaces like gtk.Widgetter, or *externglib.Object to
+// accept any object. Unexported fields are also filled.
+//
+// Every missing or mismatched id is appended to the builder errors, which are
+// returned for convenience.
+//
+//   type CustomWidget struct {
+//     gtk.Box `gtk:"mainbox"`
+//     cut     *gtk.Button   `gtk:"cut"`
+//     text    *gtk.TextView `gtk:"text"`
+//   }
+//
+func (b *BuildHelp) Bind(ptr interface{}) grun.Errors {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		b.errors.Append(fmt.Errorf(FmtErrBind, ptr))
+		return b.errors
+	}
+
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Tag.Get(TagName)
+		if name == "" || name == "-" {
+			continue
+		}
+
+		field := v.Field(i)
+		if !field.CanSet() { // Unexported field.
+			field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+		}
+		b.getO(typeName(field.Type()), name, func(o interface{}) bool { return setField(field, o) })
+	}
+	return b.errors
+}
+
+var typeObject = reflect.TypeOf((*externglib.Object)(nil))
+
+// setField sets the object to the field if its type is compatible.
+func setField(field reflect.Value, o interface{}) bool {
+	if field.Type() == typeObject {
+		field.Set(reflect.ValueOf(externglib.InternObject(o.(externglib.Objector))))
+		return true
+	}
+
+	v := reflect.ValueOf(o)
+	switch {
+	case v.Type().AssignableTo(field.Type()):
+		field.Set(v)
+
+	case v.Kind() == reflect.Ptr && v.Elem().Type() == field.Type(): // Embedded value.
+		field.Set(v.Elem())
+
+	default:
+		return false
+	}
+	return true
+}
+
+// typeName returns the type name without package and pointer, like Button.
+func typeName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Name() == "" {
+		return t.String()
+	}
+	return t.Name()
+}
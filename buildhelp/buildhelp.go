@@ -2,26 +2,41 @@
 package buildhelp
 
 import (
-	"fmt"
-
 	"github.com/gtkool4/grun"
+	"github.com/gtkool4/gtkelp/uidsl"
+	"github.com/gtkool4/gtkelp/uixml"
 
+	externglib "github.com/diamondburned/gotk4/pkg/core/glib"
 	"github.com/diamondburned/gotk4/pkg/gtk/v4"
 )
 
+//go:generate go run ./internal/accessorgen -o accessors.go
+
 // Errors formating.
 var (
 	FmtErrBadType  = "builder has bad type for key %s not a %s"
 	FmtErrNotFound = "builder found no object for key %s (%s)"
 )
 
+// parser parses an interface in another format than XML, like uixml.ParseJSON.
+// A nil parser reads XML.
+type parser func(data []byte, file string) (*uixml.Node, error)
+
 //
 //------------------------------------------------------------[ BUILD HELPER ]--
 
 // BuildHelp is a small wrapper around gtk.Builder to load interfaces easily.
 type BuildHelp struct {
 	gtk.Builder
-	errors grun.Errors
+	errors  grun.Errors
+	signals []signal           // Declared in the interface, to connect with ConnectSignals.
+	files   []string           // Loaded interface files, with includes.
+	css     []*gtk.CSSProvider // Loaded with <css file="style.css"/>.
+	tmpDir  string             // Files extracted from a fs.FS.
+	objects Audit              // Declared with an id, for Audit.
+	fetched map[string]string  // Accessor type by id.
+
+	lookup func(id string) *externglib.Object // Replaces GetObject for composite templates.
 }
 
 // New creates a *BuildHelp to load gtk.Builder interfaces easily.
@@ -30,203 +45,153 @@ func New() *BuildHelp {
 }
 
 // NewFromString creates a *BuildHelp to load gtk.Builder interfaces easily from a string.
-func NewFromString(str string) *BuildHelp {
-	return &BuildHelp{Builder: *gtk.NewBuilderFromString(str, -1)}
-}
+//
+// Panics if the interface is invalid, see ParseString to get the error.
+func NewFromString(str string) *BuildHelp { return must(ParseString(str)) }
 
 // NewFromFile creates a *BuildHelp to load gtk.Builder interfaces easily from a file.
-func NewFromFile(file string) *BuildHelp {
-	return &BuildHelp{Builder: *gtk.NewBuilderFromFile(file)}
-}
-
-// Errors returns builder errors: bad types or not found.
-func (b *BuildHelp) Errors() grun.Errors { return b.errors }
-
-func (b *BuildHelp) getO(typ, name string, call func(interface{}) bool) {
-	obj := b.GetObject(name)
-	if obj == nil {
-		b.errors.Append(fmt.Errorf(FmtErrNotFound, name, typ))
-		return
-	}
-
-	if !call(obj.Cast()) {
-		b.errors.Append(fmt.Errorf(FmtErrBadType, name, typ))
-	}
-}
-
 //
-//-----------------------------------------------------------------[ WIDGETS ]--
+// Panics if the interface is invalid, see ParseFile to get the error.
+func NewFromFile(file string) *BuildHelp { return must(ParseFile(file)) }
 
-// Adjustment get the named object as Adjustment.
-func (b *BuildHelp) Adjustment(name string) (w *gtk.Adjustment) {
-	b.getO("Adjustment", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Adjustment); return })
-	return w
-}
-
-// ApplicationWindow get the named object as ApplicationWindow.
-func (b *BuildHelp) ApplicationWindow(name string) (w *gtk.ApplicationWindow) {
-	b.getO("ApplicationWindow", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ApplicationWindow); return })
-	return w
-}
-
-// Box get the named object as Box.
-func (b *BuildHelp) Box(name string) (w *gtk.Box) {
-	b.getO("Box", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Box); return })
-	return w
-}
-
-// Button get the named object as Button.
-func (b *BuildHelp) Button(name string) (w *gtk.Button) {
-	b.getO("Button", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Button); return })
-	return w
-}
-
-// CellRendererText get the named object as CellRendererText.
-func (b *BuildHelp) CellRendererText(name string) (w *gtk.CellRendererText) {
-	b.getO("CellRendererText", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CellRendererText); return })
-	return w
-}
-
-// CheckButton get the named object as CheckButton.
-func (b *BuildHelp) CheckButton(name string) (w *gtk.CheckButton) {
-	b.getO("CheckButton", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.CheckButton); return })
-	return w
-}
-
-// ComboBox get the named object as ComboBox.
-func (b *BuildHelp) ComboBox(name string) (w *gtk.ComboBox) {
-	b.getO("ComboBox", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ComboBox); return })
-	return w
-}
-
-// Dialog get the named object as Dialog.
-func (b *BuildHelp) Dialog(name string) (w *gtk.Dialog) {
-	b.getO("Dialog", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Dialog); return })
-	return w
-}
-
-// Frame get the named object as Frame.
-func (b *BuildHelp) Frame(name string) (w *gtk.Frame) {
-	b.getO("Frame", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Frame); return })
-	return w
-}
-
-// HeaderBar get the named object as HeaderBar.
-func (b *BuildHelp) HeaderBar(name string) (w *gtk.HeaderBar) {
-	b.getO("HeaderBar", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.HeaderBar); return })
-	return w
-}
-
-// IconView get the named object as IconView.
-func (b *BuildHelp) IconView(name string) (w *gtk.IconView) {
-	b.getO("IconView", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.IconView); return })
-	return w
-}
-
-// Image get the named object as Image.
-func (b *BuildHelp) Image(name string) (w *gtk.Image) {
-	b.getO("Image", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Image); return })
-	return w
-}
-
-// Label get the named object as Label.
-func (b *BuildHelp) Label(name string) (w *gtk.Label) {
-	b.getO("Label", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Label); return })
-	return w
-}
-
-// ListStore get the named object as ListStore.
-func (b *BuildHelp) ListStore(name string) (w *gtk.ListStore) {
-	b.getO("ListStore", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ListStore); return })
-	return w
-}
-
-// ScrolledWindow get the named object as ScrolledWindow.
-func (b *BuildHelp) ScrolledWindow(name string) (w *gtk.ScrolledWindow) {
-	b.getO("ScrolledWindow", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ScrolledWindow); return })
-	return w
+// ParseString creates a *BuildHelp from an interface string, or returns a
+// *ParseError if the interface is invalid.
+//
+// Includes and file references are relative to the current directory, see
+// ParseFS.
+func ParseString(str string) (*BuildHelp, error) {
+	return New().loadString(str, nil)
 }
 
-// Scale get the named object as Scale.
-func (b *BuildHelp) Scale(name string) (w *gtk.Scale) {
-	b.getO("Scale", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Scale); return })
-	return w
-}
+// NewFromJSON creates a *BuildHelp from an interface in the JSON format of
+// uixml.ParseJSON.
+//
+// Panics if the interface is invalid, see ParseJSON to get the error.
+func NewFromJSON(str string) *BuildHelp { return must(ParseJSON(str)) }
 
-// ShortcutsWindow get the named object as ShortcutsWindow.
-func (b *BuildHelp) ShortcutsWindow(name string) (w *gtk.ShortcutsWindow) {
-	b.getO("ShortcutsWindow", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ShortcutsWindow); return })
-	return w
-}
+// NewFromYAML creates a *BuildHelp from an interface in the YAML format of
+// uixml.ParseYAML.
+//
+// Panics if the interface is invalid, see ParseYAML to get the error.
+func NewFromYAML(str string) *BuildHelp { return must(ParseYAML(str)) }
 
-// Stack get the named object as Stack.
-func (b *BuildHelp) Stack(name string) (w *gtk.Stack) {
-	b.getO("Stack", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Stack); return })
-	return w
+// ParseJSON creates a *BuildHelp from an interface in JSON format, or returns a
+// *ParseError with the position in the JSON if the interface is invalid.
+func ParseJSON(str string) (*BuildHelp, error) {
+	return New().loadString(str, uixml.ParseJSON)
 }
 
-// StackSwitcher get the named object as StackSwitcher.
-func (b *BuildHelp) StackSwitcher(name string) (w *gtk.StackSwitcher) {
-	b.getO("StackSwitcher", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.StackSwitcher); return })
-	return w
+// ParseYAML creates a *BuildHelp from an interface in YAML format, or returns a
+// *ParseError with the position in the YAML if the interface is invalid.
+func ParseYAML(str string) (*BuildHelp, error) {
+	return New().loadString(str, uixml.ParseYAML)
 }
 
-// Switch get the named object as Switch.
-func (b *BuildHelp) Switch(name string) (w *gtk.Switch) {
-	b.getO("Switch", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Switch); return })
-	return w
-}
+// NewFromDSL creates a *BuildHelp from an interface in the syntax of the uidsl
+// package.
+//
+// Panics if the interface is invalid, see ParseDSL to get the error.
+func NewFromDSL(str string) *BuildHelp { return must(ParseDSL(str)) }
 
-// TextView get the named object as TextView.
-func (b *BuildHelp) TextView(name string) (w *gtk.TextView) {
-	b.getO("TextView", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TextView); return })
-	return w
+// ParseDSL creates a *BuildHelp from an interface in the syntax of the uidsl
+// package, or returns a *ParseError with the position in the source if the
+// interface is invalid.
+func ParseDSL(str string) (*BuildHelp, error) {
+	return New().loadString(str, uidsl.Parse)
 }
 
-// ToggleButton get the named object as ToggleButton.
-func (b *BuildHelp) ToggleButton(name string) (w *gtk.ToggleButton) {
-	b.getO("ToggleButton", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.ToggleButton); return })
-	return w
+// ParseFile creates a *BuildHelp from an interface file, or returns a
+// *ParseError if the interface is invalid. Files with an extension of
+// uixml.Parsers, like .json, .yaml or .uidsl, are converted.
+//
+// Includes and file references are relative to the file, see ParseFS.
+func ParseFile(file string) (*BuildHelp, error) {
+	return New().loadFiles(diskSource{}, []string{file})
 }
 
-// TreeModelFilter get the named object as TreeModelFilter.
-func (b *BuildHelp) TreeModelFilter(name string) (w *gtk.TreeModelFilter) {
-	b.getO("TreeModelFilter", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeModelFilter); return })
-	return w
+func (b *BuildHelp) loadString(str string, parse parser) (*BuildHelp, error) {
+	if e := b.load(diskSource{}, []byte(str), "", parse); e != nil {
+		b.Close()
+		return nil, e
+	}
+	return b, nil
 }
 
-// TreeModelSort get the named object as TreeModelSort.
-func (b *BuildHelp) TreeModelSort(name string) (w *gtk.TreeModelSort) {
-	b.getO("TreeModelSort", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeModelSort); return })
-	return w
+func must(b *BuildHelp, e error) *BuildHelp {
+	if e != nil {
+		panic(e)
+	}
+	return b
 }
 
-// TreeSelection get the named object as TreeSelection.
-func (b *BuildHelp) TreeSelection(name string) (w *gtk.TreeSelection) {
-	b.getO("TreeSelection", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeSelection); return })
-	return w
+// load adds the interface to the builder. Includes are expanded and signals are
+// extracted to be connected with ConnectSignals, as gtk.Builder can't find go
+// handlers. Strings are translated with Translations if set.
+//
+// Unchanged files are loaded directly, so gtk.Builder can still resolve paths
+// relative to the file.
+//
+// Interfaces in other formats are parsed with parse, and always rendered for
+// gtk.Builder.
+//
+// Errors are returned as *ParseError, with positions in the source.
+func (b *BuildHelp) load(src source, data []byte, file string, parse parser) error {
+	native := parse == nil
+	if native {
+		parse = uixml.ParseBytes
+	}
+	root, e := parse(data, file)
+	if e != nil {
+		return parseError(e, file, nil)
+	}
+	changed, e := b.expand(src, root, file, nil)
+	if e != nil {
+		return parseError(e, file, nil)
+	}
+	b.auditObjects(root)
+	changed = translate(root) || changed
+
+	switch {
+	case b.extractSignals(root) || changed || !native:
+		data, lines := root.Render()
+		e = b.AddFromString(string(data), -1)
+		if e != nil {
+			return parseError(e, file, lines)
+		}
+
+	case file != "" && src == diskSource{}:
+		e = b.AddFromFile(file)
+
+	default:
+		e = b.AddFromString(string(data), -1)
+	}
+	if e != nil {
+		return parseError(e, file, nil)
+	}
+	return nil
 }
 
-// TreeStore get the named object as TreeStore.
-func (b *BuildHelp) TreeStore(name string) (w *gtk.TreeStore) {
-	b.getO("TreeStore", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeStore); return })
-	return w
-}
+// Errors returns builder errors: bad types or not found.
+func (b *BuildHelp) Errors() grun.Errors { return b.errors }
 
-// TreeView get the named object as TreeView.
-func (b *BuildHelp) TreeView(name string) (w *gtk.TreeView) {
-	b.getO("TreeView", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeView); return })
-	return w
-}
+func (b *BuildHelp) getO(typ, name string, call func(interface{}) bool) {
+	b.fetch(name, typ)
+	obj := b.object(name)
+	if obj == nil {
+		b.errors.Append(&NotFoundError{ID: name, Type: typ})
+		return
+	}
 
-// TreeViewColumn get the named object as TreeViewColumn.
-func (b *BuildHelp) TreeViewColumn(name string) (w *gtk.TreeViewColumn) {
-	b.getO("TreeViewColumn", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.TreeViewColumn); return })
-	return w
+	if cast := obj.Cast(); !call(cast) {
+		b.errors.Append(&BadTypeError{ID: name, Type: typ, Found: cast})
+	}
 }
 
-// Window get the named object as Window.
-func (b *BuildHelp) Window(name string) (w *gtk.Window) {
-	b.getO("Window", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.Window); return })
-	return w
+// object returns the object with the id, from the builder or the composite
+// template.
+func (b *BuildHelp) object(id string) *externglib.Object {
+	if b.lookup != nil {
+		return b.lookup(id)
+	}
+	return b.GetObject(id)
 }
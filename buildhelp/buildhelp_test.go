@@ -1,16 +1,28 @@
 package buildhelp_test
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
 
 	"github.com/gtkool4/grun"
 	"github.com/gtkool4/gtkelp/buildhelp"
+	"github.com/gtkool4/gtkelp/gettext"
+	"github.com/gtkool4/gtkelp/gtkext"
+	"github.com/gtkool4/gtkelp/gtknew"
 
+	externglib "github.com/diamondburned/gotk4/pkg/core/glib"
 	"github.com/diamondburned/gotk4/pkg/gtk/v4"
 )
 
+//go:generate go run ../cmd/uidsl example_test.uidsl
+//go:generate go run ../cmd/uigen -type ExampleUI -o example_ui_test.go example_test.ui
+
 //
 //-----------------------------------------------------------------[ TESTS ]--
 
@@ -39,6 +51,12 @@ var tests = map[string]func(t *testing.T, b *buildhelp.BuildHelp){
 		}
 		testErr(t, b.Errors(), "builder has bad type for key quit not a Adjustment")
 	},
+	"GeneratedAccessor": func(t *testing.T, b *buildhelp.BuildHelp) {
+		if b.Separator("sep") == nil || b.HeaderBar("menubar") == nil {
+			t.Error("generated accessors should find sep and menubar")
+		}
+		testExpectedString(t, "", b.Errors().Error())
+	},
 	"MessageEmpty": func(t *testing.T, b *buildhelp.BuildHelp) {
 		testExpectedString(t, "", b.Errors().Error())
 	},
@@ -65,6 +83,619 @@ var tests = map[string]func(t *testing.T, b *buildhelp.BuildHelp){
 		)
 		testExpectedString(t, expected, b.Errors().Error())
 	},
+	"Bind": func(t *testing.T, b *buildhelp.BuildHelp) {
+		var w struct {
+			gtk.Box `gtk:"mainbox"`
+			cut     *gtk.Button        `gtk:"cut"`
+			sep     gtk.Widgetter      `gtk:"sep"`
+			Menu    *externglib.Object `gtk:"menubar"`
+			Skipped *gtk.Button        `gtk:"-"`
+		}
+		testExpectedString(t, "", b.Bind(&w).Error())
+		if w.cut == nil || w.sep == nil || w.Menu == nil || w.Box.Native() == 0 {
+			t.Error("bind should have filled all tagged fields")
+		}
+		if w.Skipped != nil {
+			t.Error("bind should ignore fields tagged -")
+		}
+	},
+	"BindErrors": func(t *testing.T, b *buildhelp.BuildHelp) {
+		var w struct {
+			missing *gtk.Button `gtk:"fail"`
+			quit    *gtk.Label  `gtk:"quit"`
+		}
+		expected := fmt.Sprintf(
+			buildhelp.FmtErrNotFound+"\n"+buildhelp.FmtErrBadType,
+			"fail", "Button", "quit", "Label",
+		)
+		testExpectedString(t, expected, b.Bind(&w).Error())
+		if w.quit != nil {
+			t.Error("widget quit should NOT be bound as a Label")
+		}
+	},
+	"Generated": func(t *testing.T, b *buildhelp.BuildHelp) {
+		ui, errs := NewExampleUI(b)
+		testExpectedString(t, "", errs.Error())
+		if ui.Cut == nil || ui.Mainbox == nil || ui.Text == nil {
+			t.Error("generated struct should have all objects")
+		}
+	},
+	"Signals": func(t *testing.T, _ *buildhelp.BuildHelp) {
+		b := buildhelp.NewFromString(uiSignals)
+		var clicked, notified bool
+		errs := b.ConnectSignals(map[string]interface{}{
+			"Clicked":  func() { clicked = true },
+			"Notified": func(*gtk.Button) { notified = true },
+			"Unused":   func() {},
+			"Ignored":  func() {},
+		})
+		expected := fmt.Sprintf( // Unused handlers are sorted.
+			buildhelp.FmtErrHandlerNotFound+"\n"+buildhelp.FmtErrHandlerUnused+"\n"+buildhelp.FmtErrHandlerUnused,
+			"Missing", "clicked", fmt.Sprintf(buildhelp.FmtSignalID, 2), "Ignored", "Unused",
+		)
+		testExpectedString(t, expected, errs.Error())
+
+		ok := b.Button("ok")
+		ok.Emit("clicked")
+		ok.SetLabel("updated")
+		if !clicked || !notified {
+			t.Error("signals should call the connected handlers")
+		}
+	},
+	"SignalsArity": func(t *testing.T, _ *buildhelp.BuildHelp) {
+		b := buildhelp.NewFromString(uiSignals)
+		errs := b.ConnectSignals(map[string]interface{}{
+			"Clicked":  func(*gtk.Button, int) {},
+			"Notified": func(*gtk.Button, *externglib.Object) {},
+			"Missing":  "not a func",
+		})
+		expected := fmt.Sprintf(
+			buildhelp.FmtErrHandlerArgs+"\n"+buildhelp.FmtErrHandlerNotFunc,
+			"Clicked", 2, "clicked", "ok", 1,
+			"Missing", "clicked", fmt.Sprintf(buildhelp.FmtSignalID, 2), "",
+		)
+		testExpectedString(t, expected, errs.Error())
+
+		b = buildhelp.NewFromString(uiSignals)
+		testExpectedString(t, buildhelp.TxtErrHandlersNil, b.ConnectSignals(nil).Error())
+	},
+	"TypedErrors": func(t *testing.T, b *buildhelp.BuildHelp) {
+		b.Label("quit")
+		b.Label("fail")
+		var badType *buildhelp.BadTypeError
+		var notFound *buildhelp.NotFoundError
+		if !errors.As(b.Errors()[0], &badType) || badType.ID != "quit" || badType.Type != "Label" {
+			t.Errorf("error should be a *BadTypeError for quit: %#v", b.Errors()[0])
+		}
+		if _, ok := badType.Found.(*gtk.Button); !ok {
+			t.Errorf("bad type error should have found a *gtk.Button, not %T", badType.Found)
+		}
+		if !errors.As(b.Errors()[1], &notFound) || notFound.ID != "fail" {
+			t.Errorf("error should be a *NotFoundError for fail: %#v", b.Errors()[1])
+		}
+	},
+	"ParseErrors": func(t *testing.T, _ *buildhelp.BuildHelp) {
+		for _, test := range []struct{ str, pos string }{
+			{"<interface>\n  <object class='GtkNope'/>\n</interface>", "<input>:2"},
+			{"<interface>\n  <object>\n</interface>", "<input>:3:1"}, // Malformed XML.
+			{"<interface>\n  <object class='GtkNope'>\n    <signal name='x' handler='X'/>\n  </object>\n</interface>", "<input>:2:3"},
+		} {
+			str, expected := test.str, test.pos
+			b, e := buildhelp.ParseString(str)
+			var parseErr *buildhelp.ParseError
+			switch {
+			case b != nil:
+				t.Errorf("parse %q should fail", str)
+
+			case !errors.As(e, &parseErr):
+				t.Errorf("parse %q should return a *ParseError, not %v", str, e)
+
+			case !strings.HasPrefix(parseErr.Pos.String(), expected):
+				t.Errorf("parse %q error should be at %s: %v", str, expected, e)
+			}
+		}
+		if _, e := buildhelp.ParseFile("missing.ui"); !errors.Is(e, os.ErrNotExist) {
+			t.Error("parse of a missing file should return os.ErrNotExist, not", e)
+		}
+	},
+	"YAML": func(t *testing.T, _ *buildhelp.BuildHelp) {
+		b := buildhelp.NewFromYAML(uiYAML)
+		var clicked bool
+		errs := b.ConnectSignals(map[string]interface{}{"Clicked": func() { clicked = true }})
+		testExpectedString(t, "", errs.Error())
+		b.Button("ok").Emit("clicked")
+		if !clicked || b.Box("main") == nil || b.Button("ok").Label() != "OK" {
+			t.Error("yaml interface should load objects, properties and signals")
+		}
+
+		b = buildhelp.NewFromJSON(`{"objects": [{"class": "GtkLabel", "id": "label", "properties": {"label": "JSON"}}]}`)
+		testExpectedString(t, "JSON", b.Label("label").Label())
+
+		_, e := buildhelp.ParseYAML("objects:\n  - class: GtkBox\n    properties:\n      nope: 1\n")
+		var parseErr *buildhelp.ParseError
+		if !errors.As(e, &parseErr) || parseErr.Pos.Line != 4 {
+			t.Error("yaml error should be at the line of the property, not", e)
+		}
+	},
+	"DSL": func(t *testing.T, _ *buildhelp.BuildHelp) {
+		b := buildhelp.NewFromDSL("Box main {\n  Label title {\n    label: _(\"Title\");\n  }\n}\n")
+		testExpectedString(t, "Title", b.Label("title").Label())
+
+		_, e := buildhelp.ParseDSL("Box {\n  Label {\n    nope: 1;\n  }\n}\n")
+		var parseErr *buildhelp.ParseError
+		if !errors.As(e, &parseErr) || parseErr.Pos.Line != 3 {
+			t.Error("dsl error should be at the line of the property, not", e)
+		}
+	},
+	"Overlay": func(t *testing.T, _ *buildhelp.BuildHelp) {
+		b := buildhelp.NewFromDSL("Box main {\n  Label title {\n    label: \"Title\";\n  }\n}\n")
+		brand, e := buildhelp.ParseOverlay([]byte(`{
+  "title.label": "Brand",
+  "main": {"visible": false, "css-classes": ["flat", "brand"], "orientation": "vertical"}
+}`), "brand.json")
+		if e != nil {
+			t.Fatal("parse overlay:", e)
+		}
+		local := buildhelp.NewOverlay().Set("title.label", "Local")
+		testExpectedString(t, "", b.ApplyOverlay(brand, local).Error())
+		testExpectedString(t, "Local", b.Label("title").Label())
+		main := b.Box("main")
+		if main.Visible() || strings.Join(main.CSSClasses(), " ") != "flat brand" || main.Orientation() != gtk.OrientationVertical {
+			t.Error("overlay should hide main, set its css classes and orientation")
+		}
+
+		bad, _ := buildhelp.ParseOverlay([]byte(`{"nope.label": "x", "title.nope": "x", "main.visible": "maybe"}`), "bad.json")
+		testExpectedString(t, strings.Join([]string{
+			fmt.Sprintf(buildhelp.FmtErrOverlay, "bad.json", "nope.label", fmt.Sprintf(buildhelp.FmtErrOverlayID, "nope")),
+			fmt.Sprintf(buildhelp.FmtErrOverlay, "bad.json", "title.nope", fmt.Sprintf(buildhelp.FmtErrOverlayProperty, "GtkLabel", "nope")),
+		}, "\n"), strings.Join(strings.Split(b.ApplyOverlay(bad).Error(), "\n")[:2], "\n"))
+
+		_, e = buildhelp.ParseOverlay([]byte("{\n  \"title.label\": null\n}"), "null.json")
+		var parseErr *buildhelp.ParseError
+		if !errors.As(e, &parseErr) || parseErr.Pos.Line != 2 {
+			t.Error("overlay error should be at the line of the value, not", e)
+		}
+		for pad := 480; pad < 560; pad++ { // Group keys past the decoder buffer.
+			src := fmt.Sprintf(`{"title.label": "%s", "main": {"css-classes": ["a", "b"]}}`, strings.Repeat("x", pad))
+			long, e := buildhelp.ParseOverlay([]byte(src), "long.json")
+			if e != nil {
+				t.Fatalf("overlay with %d bytes before a group should parse: %v", pad, e)
+			}
+			if value, _ := long.Get("main.css-classes"); value != "a\nb" {
+				t.Fatalf("overlay with %d bytes before a group should set the group values, have %q", pad, value)
+			}
+		}
+		for src, expected := range map[string]string{
+			"":                               "empty.json:1:1: " + buildhelp.TxtErrOverlayRoot,
+			"{\"a.b\": \"x\"}\n{\"c.d\": 1}": "empty.json:2:1: " + buildhelp.TxtErrOverlayTrailing,
+		} {
+			_, e = buildhelp.ParseOverlay([]byte(src), "empty.json")
+			if !errors.As(e, &parseErr) || e.Error() != expected {
+				t.Errorf("overlay %q error should be %q, not %v", src, expected, e)
+			}
+		}
+	},
+	"BindNotPointer": func(t *testing.T, b *buildhelp.BuildHelp) {
+		testErr(t, b.Bind(struct{}{}), fmt.Sprintf(buildhelp.FmtErrBind, struct{}{}))
+	},
+	"FS": func(t *testing.T, _ *buildhelp.BuildHelp) {
+		b, e := buildhelp.ParseFS(uiFS, "ui/main.ui", "ui/other.ui")
+		if e != nil {
+			t.Fatal("parse fs:", e)
+		}
+		defer b.Close()
+		if b.Box("main") == nil || b.Label("title") == nil || b.Button("other") == nil {
+			t.Error("fs builder should find main, title from the include, and other")
+		}
+		picture := b.Picture("logo")
+		if picture == nil || picture.File() == nil || !strings.HasSuffix(picture.File().Path(), "logo.svg") {
+			t.Error("picture file should be extracted from the fs")
+		}
+		testExpectedString(t, "", b.Errors().Error())
+	},
+	"FSErrors": func(t *testing.T, _ *buildhelp.BuildHelp) {
+		for file, expected := range map[string]string{
+			"ui/cycle.ui":   "ui/cycle.ui:2:3: include cycle: ui/cycle.ui > ui/cycle.ui",
+			"ui/missing.ui": "ui/missing.ui:2:3: include ui/nope.ui: open ui/nope.ui: file does not exist",
+		} {
+			_, e := buildhelp.ParseFS(uiFS, file)
+			var parseErr *buildhelp.ParseError
+			if !errors.As(e, &parseErr) {
+				t.Errorf("parse %s should return a *ParseError, not %v", file, e)
+				continue
+			}
+			testExpectedString(t, expected, e.Error())
+		}
+	},
+	"Translations": func(t *testing.T, _ *buildhelp.BuildHelp) {
+		e := buildhelp.LoadTranslations(uiFS, "app", "fr_FR.UTF-8")
+		if e != nil {
+			t.Fatal("load translations:", e)
+		}
+		defer func() { buildhelp.Translations, gtkext.Translate = nil, func(msg string) string { return msg } }()
+
+		b := buildhelp.NewFromString(`<interface>
+  <object class="GtkButton" id="cut">
+    <property name="label" translatable="yes">Cut</property>
+    <property name="tooltip-text" translatable="yes" context="menu">Open</property>
+  </object>
+</interface>`)
+		testExpectedString(t, "Couper", b.Button("cut").Label())
+		testExpectedString(t, "Ouvrir", b.Button("cut").TooltipText())
+		testExpectedString(t, "Couper", gtkext.Tr("Cut"))
+		testExpectedString(t, "2 fichiers", fmt.Sprintf(buildhelp.NGettext("%d file", "%d files", 2), 2))
+
+		e = buildhelp.LoadTranslations(uiFS, "app", "de")
+		if !errors.Is(e, gettext.ErrNotFound) {
+			t.Error("missing translations should wrap gettext.ErrNotFound:", e)
+		}
+	},
+	"Reloader": func(t *testing.T, _ *buildhelp.BuildHelp) {
+		file := filepath.Join(t.TempDir(), "reload.ui")
+		write := func(str string) {
+			if e := os.WriteFile(file, []byte(str), 0600); e != nil {
+				t.Fatal(e)
+			}
+		}
+		write(`<interface><object class="GtkLabel" id="label"><property name="label">one</property></object></interface>`)
+
+		r := buildhelp.NewReloader(file, func(b *buildhelp.BuildHelp) (gtk.Widgetter, grun.Errors) {
+			return b.Label("label"), b.Errors()
+		})
+		defer r.Stop()
+		first := r.Root()
+		if first == nil || r.Errors() != "" {
+			t.Fatal("reloader first build should succeed:", r.Errors())
+		}
+		var notified grun.Errors
+		r.OnReload(func(errs grun.Errors) { notified = errs })
+
+		write(`<interface><object class="GtkLabel" id="label">`)
+		if r.Reload() == nil || r.Errors() == "" || r.Root() != first {
+			t.Error("reloader should keep the previous tree and show errors")
+		}
+		if !notified.IsError() {
+			t.Error("reloader should notify the reload errors")
+		}
+
+		write(`<interface><object class="GtkLabel" id="label"><property name="label">two</property></object></interface>`)
+		if errs := r.Reload(); errs != nil {
+			t.Error("reloader should rebuild the fixed file:", errs)
+		}
+		if label, ok := r.Root().(*gtk.Label); !ok || label.Label() != "two" || r.Errors() != "" {
+			t.Error("reloader should swap the new label")
+		}
+	},
+	"Composite": func(t *testing.T, _ *buildhelp.BuildHelp) {
+		compositeOnce.Do(func() { _, compositeErr = buildhelp.RegisterComposite(&testComposite{}, uiComposite) })
+		if compositeErr != nil {
+			t.Fatal("register composite:", compositeErr)
+		}
+		if _, e := buildhelp.RegisterComposite(&testComposite{}, uiComposite); e == nil {
+			t.Error("composite registered twice should fail")
+		}
+
+		b := buildhelp.NewFromString(`<interface>
+  <object class="GtkBox" id="box">
+    <child><object class="TestComposite" id="composite"/></child>
+  </object>
+</interface>`)
+		var ui struct {
+			composite *testComposite `gtk:"composite"`
+		}
+		if errs := b.Bind(&ui); errs.IsError() {
+			t.Fatal("bind composite:", errs)
+		}
+		if ui.composite.label == nil || ui.composite.CSSName() != "testcomposite" {
+			t.Error("composite should bind its template children and use its css name")
+		}
+		ui.composite.button.Emit("clicked")
+		if ui.composite.clicked != 1 {
+			t.Error("composite template signal should call the method")
+		}
+	},
+	"Template": func(t *testing.T, b *buildhelp.BuildHelp) {
+		tmpl, e := buildhelp.NewTemplate(uiTemplate)
+		if e != nil {
+			t.Fatal("template:", e)
+		}
+		one, e1 := tmpl.New()
+		two, e2 := tmpl.New()
+		if e1 != nil || e2 != nil {
+			t.Fatal("template instances:", e1, e2)
+		}
+		if one.Entry("entry").Native() == two.Entry("entry").Native() {
+			t.Error("template instances should not share objects")
+		}
+
+		rowA, e1 := tmpl.NewIn(b, "a.")
+		rowB, e2 := tmpl.NewIn(b, "b.")
+		if e1 != nil || e2 != nil {
+			t.Fatal("template instances in builder:", e1, e2)
+		}
+		if b.Entry("a.entry") == nil || b.Entry("b.entry") == nil {
+			t.Error("template ids should be prefixed in the builder")
+		}
+		mnemonic := externglib.InternObject(rowB.Label("label").MnemonicWidget())
+		if mnemonic.Native() != rowB.Entry("entry").Native() {
+			t.Error("template references should be prefixed")
+		}
+		testExpectedString(t, "", rowA.Errors().Error()+rowB.Errors().Error())
+
+		var parseErr *buildhelp.ParseError
+		if _, e := buildhelp.NewTemplate("<interface>\n  <object class='GtkNope'/>\n</interface>"); !errors.As(e, &parseErr) {
+			t.Error("invalid template should return a *ParseError, not", e)
+		}
+	},
+	"Audit": func(t *testing.T, b *buildhelp.BuildHelp) {
+		unused := b.Audit().Unused()
+		if len(unused) != 1 || unused[0].ID != "sep" || unused[0].Class != "GtkSeparator" {
+			t.Errorf("audit should only find sep unused:\n%s", unused)
+		}
+		for _, entry := range b.Audit() {
+			if entry.ID == "cut" && entry.Accessor != "Button" || entry.ID == "menubar" && entry.Accessor != "HeaderBar" {
+				t.Error("audit should report the fetch type:", entry)
+			}
+		}
+		testExpectedString(t, "<input>:29:11: sep (GtkSeparator) unused", unused.String())
+
+		testErr(t, b.WarnUnused(), "<input>:29:11: builder object sep (GtkSeparator) is never fetched nor referenced")
+		b.Separator("sep")
+		if len(b.Audit().Unused()) != 0 {
+			t.Error("audit should find sep fetched")
+		}
+
+		texts := buildhelp.NewFromString(`<interface>
+  <object class="GtkLabel" id="name">
+    <property name="label">entry</property>
+    <property name="mnemonic-widget">entry</property>
+  </object>
+  <object class="GtkLabel" id="other">
+    <property name="label">name</property>
+  </object>
+  <object class="GtkEntry" id="entry"/>
+</interface>`)
+		unused = texts.Audit().Unused()
+		if len(unused) != 2 || unused[0].ID != "name" || unused[1].ID != "other" {
+			t.Errorf("audit should only count object properties as references:\n%s", unused)
+		}
+	},
+	"Serialize": func(t *testing.T, _ *buildhelp.BuildHelp) {
+		title := gtk.NewLabel("Title")
+		title.SetName("title")
+		title.AddCSSClass("heading")
+		ok := gtk.NewButton()
+		ok.SetName("ok")
+		ok.SetLabel("OK")
+		stack := gtk.NewStack()
+		stack.AddTitled(gtk.NewLabel("page"), "first", "First")
+		root := gtknew.VBox(6,
+			title,
+			gtknew.CenterBox(nil, ok, gtknew.Frame("Options", stack)),
+			gtknew.VPaned(gtk.NewLabel("top"), gtknew.ScrolledWindow(gtk.NewTextView())),
+		)
+		root.SetName("root")
+
+		str := buildhelp.Serialize(root).String()
+		for _, expected := range []string{
+			`<object class="GtkBox" id="root">`,
+			`<property name="orientation">vertical</property>`,
+			`<property name="spacing">6</property>`,
+			`<class name="heading"/>`,
+			`<child type="center">`,
+			`<child type="end">`,
+			`<property name="label">Options</property>`,
+			`<property name="title">First</property>`,
+			`<child type="start">`,
+		} {
+			if !strings.Contains(str, expected) {
+				t.Errorf("serialized tree should contain %s:\n%s", expected, str)
+			}
+		}
+		if strings.Contains(str, `type="label"`) {
+			t.Errorf("serialized frame should not write its label widget:\n%s", str)
+		}
+
+		b, e := buildhelp.ParseString(str)
+		if e != nil {
+			t.Fatal("serialized tree should load again:", e)
+		}
+		if b.Box("root") == nil || b.Label("title").Label() != "Title" || b.Button("ok").Label() != "OK" {
+			t.Error("serialized tree should keep names as ids and properties")
+		}
+		testExpectedString(t, "", b.Errors().Error())
+
+		header := gtk.NewHeaderBar()
+		header.SetName("header")
+		for i, name := range []string{"back", "menu", "search"} {
+			button := gtk.NewButton()
+			button.SetName(name)
+			if i == 0 {
+				header.PackStart(button)
+			} else {
+				header.PackEnd(button)
+			}
+		}
+		header.SetTitleWidget(gtk.NewLabel("Editor"))
+		notebook := gtk.NewNotebook()
+		notebook.AppendPage(gtk.NewLabel("untitled"), nil)
+		str = buildhelp.Serialize(gtknew.VBox(0, header, notebook)).String()
+		if strings.Contains(str, "Page 1") || strings.Contains(str, "GtkWindowControls") {
+			t.Errorf("serialized tree should not write default tabs nor window controls:\n%s", str)
+		}
+
+		b, e = buildhelp.ParseString(str)
+		if e != nil {
+			t.Fatal("serialized header bar should load again:", e)
+		}
+		for _, name := range []string{"back", "menu", "search"} {
+			b.Button(name).SetName(name) // Ids are not names once built.
+		}
+		b.HeaderBar("header").SetName("header")
+		again := buildhelp.Serialize(b.HeaderBar("header")).String()
+		testExpectedString(t, buildhelp.Serialize(header).String(), again)
+		for _, expected := range []string{
+			`<child type="start">
+      <object class="GtkButton" id="back"/>`,
+			`<child type="end">
+      <object class="GtkButton" id="menu"/>
+    </child>
+    <child type="end">
+      <object class="GtkButton" id="search"/>`,
+			`<child type="title">`,
+		} {
+			if !strings.Contains(again, expected) {
+				t.Errorf("serialized header bar should contain %s:\n%s", expected, again)
+			}
+		}
+	},
+}
+
+const uiTemplate = `<interface>
+  <object class="GtkBox" id="row">
+    <child>
+      <object class="GtkLabel" id="label">
+        <property name="label">_entry</property>
+        <property name="use-underline">1</property>
+        <property name="mnemonic-widget">entry</property>
+      </object>
+    </child>
+    <child><object class="GtkEntry" id="entry"/></child>
+  </object>
+</interface>`
+
+// testComposite is a composite widget with a template.
+type testComposite struct {
+	gtk.Box
+	label   *gtk.Label  `gtk:"label"`
+	button  *gtk.Button `gtk:"button"`
+	clicked int
+}
+
+func (w *testComposite) Clicked() { w.clicked++ }
+
+// The TestComposite GType is global, registered once for -count runs.
+var (
+	compositeOnce sync.Once
+	compositeErr  error
+)
+
+const uiComposite = `<interface>
+  <template class="TestComposite" parent="GtkBox">
+    <child><object class="GtkLabel" id="label"/></child>
+    <child>
+      <object class="GtkButton" id="button">
+        <signal name="clicked" handler="Clicked"/>
+      </object>
+    </child>
+  </template>
+</interface>`
+
+var uiYAML = `objects:
+  - class: GtkBox
+    id: main
+    children:
+      - class: GtkButton
+        id: ok
+        properties:
+          label: OK
+        signals:
+          clicked: Clicked
+`
+
+var uiFS = fstest.MapFS{
+	"ui/main.ui": {Data: []byte(`<interface>
+  <object class="GtkBox" id="main">
+    <child>
+      <include file="parts/title.ui"/>
+    </child>
+    <child>
+      <object class="GtkPicture" id="logo">
+        <property name="file">../icons/logo.svg</property>
+      </object>
+    </child>
+  </object>
+  <css file="style.css"/>
+</interface>`)},
+	"ui/parts/title.ui": {Data: []byte(`<interface>
+  <requires lib="gtk" version="4.0"/>
+  <object class="GtkLabel" id="title"/>
+</interface>`)},
+	"ui/other.ui":    {Data: []byte(`<interface><object class="GtkButton" id="other"/></interface>`)},
+	"ui/style.css":   {Data: []byte(`#title { font-weight: bold; }`)},
+	"icons/logo.svg": {Data: []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="1" height="1"/>`)},
+	"ui/cycle.ui":    {Data: []byte("<interface>\n  <include file=\"cycle.ui\"/>\n</interface>")},
+	"ui/missing.ui":  {Data: []byte("<interface>\n  <include file=\"nope.ui\"/>\n</interface>")},
+	"fr/app.po": {Data: []byte(`msgid ""
+msgstr "Plural-Forms: nplurals=2; plural=(n > 1);\n"
+
+msgid "Cut"
+msgstr "Couper"
+
+msgctxt "menu"
+msgid "Open"
+msgstr "Ouvrir"
+
+msgid "%d file"
+msgid_plural "%d files"
+msgstr[0] "%d fichier"
+msgstr[1] "%d fichiers"
+`)},
+}
+
+const uiSignals = `<interface>
+  <object class="GtkButton" id="ok">
+    <signal name="clicked" handler="Clicked"/>
+    <signal name="notify::label" handler="Notified" after="yes"/>
+  </object>
+  <object class="GtkButton">
+    <signal name="clicked" handler="Missing"/>
+  </object>
+</interface>`
+
+//
+//------------------------------------------------------------[ BENCHMARKS ]--
+
+func BenchmarkNewFromString(b *testing.B) {
+	benchGtk(func() {
+		for i := 0; i < b.N; i++ {
+			buildhelp.NewFromString(uiBasic)
+		}
+	})
+}
+
+func BenchmarkTemplateNew(b *testing.B) {
+	benchGtk(func() {
+		tmpl, e := buildhelp.NewTemplate(uiBasic)
+		if e != nil {
+			b.Fatal(e)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			tmpl.New()
+		}
+	})
+}
+
+func BenchmarkTemplateNewIn(b *testing.B) {
+	benchGtk(func() {
+		tmpl, e := buildhelp.NewTemplate(uiTemplate)
+		if e != nil {
+			b.Fatal(e)
+		}
+		dst := buildhelp.New()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			tmpl.NewIn(dst, fmt.Sprintf("row%d.", i))
+		}
+	})
+}
+
+// benchGtk runs the benchmark in the gtk main loop.
+func benchGtk(call func()) {
+	gapp.Run(func(gapp *grun.App) gtk.Widgetter {
+		return gtk.NewLabel("benchmark")
+	}, func() { call(); gapp.Win.Close() })
 }
 
 //
@@ -0,0 +1,40 @@
+#include <gtk/gtk.h>
+
+#include "composite.h"
+
+extern void buildhelpCompositeInit(GObject *instance, GType gtype);
+
+// composite_class_init sets the template of the class and binds its children.
+static void composite_class_init(gpointer klass, gpointer data) {
+	CompositeClass *c = data;
+	GtkWidgetClass *widget_class = GTK_WIDGET_CLASS(klass);
+
+	gtk_widget_class_set_template(widget_class, c->template);
+	gtk_widget_class_set_css_name(widget_class, c->css_name);
+	for (int i = 0; i < c->n_children; i++) {
+		gtk_widget_class_bind_template_child_full(widget_class, c->children[i], FALSE, 0);
+	}
+}
+
+// composite_instance_init builds the template, then creates the go value.
+static void composite_instance_init(GTypeInstance *instance, gpointer klass) {
+	gtk_widget_init_template(GTK_WIDGET(instance));
+	buildhelpCompositeInit(G_OBJECT(instance), G_TYPE_FROM_CLASS(klass));
+}
+
+GType composite_register(GType parent, const char *name, CompositeClass *c) {
+	GTypeQuery query;
+	g_type_query(parent, &query);
+
+	GTypeInfo info = {0};
+	info.class_size = query.class_size;
+	info.class_init = composite_class_init;
+	info.class_data = c;
+	info.instance_size = query.instance_size;
+	info.instance_init = composite_instance_init;
+	return g_type_register_static(parent, name, &info, 0);
+}
+
+GObject *composite_child(GObject *instance, GType gtype, const char *name) {
+	return gtk_widget_get_template_child(GTK_WIDGET(instance), gtype, name);
+}
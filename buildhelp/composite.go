@@ -0,0 +1,261 @@
+package buildhelp
+
+// #cgo pkg-config: gtk4
+// #include <stdlib.h>
+// #include "composite.h"
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/gtkool4/grun"
+	"github.com/gtkool4/gtkelp/uixml"
+
+	externglib "github.com/diamondburned/gotk4/pkg/core/glib"
+)
+
+// Errors formating.
+var (
+	FmtErrCompositeExists = "composite class %s is already registered"                 // Format: class
+	FmtErrCompositeParent = "composite parent %s is not a widget class"                // Format: parent
+	FmtErrCompositeInit   = "composite %s: %s"                                         // Format: class, errors
+	FmtErrCompositeField  = "composite %s must embed its parent widget as first field" // Format: type
+	TxtErrTemplate        = "composite needs a <template class=\"Name\" parent=\"GtkParent\"> element"
+)
+
+// composites lists registered composite classes by GType.
+var composites = struct {
+	sync.Mutex
+	types     map[externglib.Type]*Composite
+	instances map[uintptr]interface{} // Go values by instance, until destroyed.
+}{
+	types:     map[externglib.Type]*Composite{},
+	instances: map[uintptr]interface{}{},
+}
+
+//
+//---------------------------------------------------------------[ COMPOSITE ]--
+
+// Composite is a widget class registered with a template, whose instances are
+// go structs. See RegisterComposite.
+type Composite struct {
+	name    string
+	gtype   externglib.Type
+	parent  externglib.Type
+	typ     reflect.Type // Struct type.
+	signals []signal
+}
+
+// RegisterComposite registers a go struct as a new widget class with a
+// <template> interface, so it can be used as <object class="Name"> in other
+// builder files, and styled with its CSS name: the class name in lower case.
+//
+// The struct must embed the parent widget first, like a gtk.Box. On each new
+// instance, the struct is created and its tagged fields are bound to the
+// template children, and the template signals are connected to its methods,
+// like Bind and ConnectSignals.
+//
+// The struct is the go value of the instance: Bind or Cast return it. It is kept
+// until the widget is destroyed.
+//
+// Translatable strings of the template are translated with Translations when
+// registered.
+//
+//   type MyWidget struct {
+//     gtk.Box
+//     label *gtk.Label `gtk:"label"`
+//   }
+//
+//   myWidget, e := buildhelp.RegisterComposite(&MyWidget{}, `<interface>
+//     <template class="MyWidget" parent="GtkBox">
+//       <child><object class="GtkLabel" id="label"/></child>
+//     </template>
+//   </interface>`)
+//
+func RegisterComposite(ptr interface{}, template string) (*Composite, error) {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf(FmtErrBind, ptr)
+	}
+
+	root, e := uixml.ParseString(template, "")
+	if e != nil {
+		return nil, parseError(e, "", nil)
+	}
+	var tmpl *uixml.Node
+	for _, n := range root.Elements("template") {
+		tmpl = n
+	}
+	if tmpl == nil || tmpl.Attr("class") == "" || tmpl.Attr("parent") == "" {
+		return nil, errors.New(TxtErrTemplate)
+	}
+
+	c := &Composite{
+		name:   tmpl.Attr("class"),
+		parent: externglib.TypeFromName(tmpl.Attr("parent")),
+		typ:    v.Elem().Type(),
+	}
+	switch {
+	case externglib.TypeFromName(c.name) != 0:
+		return nil, fmt.Errorf(FmtErrCompositeExists, c.name)
+
+	case c.parent == 0 || !c.parent.IsA(externglib.TypeFromName("GtkWidget")):
+		return nil, fmt.Errorf(FmtErrCompositeParent, tmpl.Attr("parent"))
+
+	case c.typ.NumField() == 0 || !c.typ.Field(0).Anonymous:
+		return nil, fmt.Errorf(FmtErrCompositeField, c.typ)
+	}
+
+	b := &BuildHelp{}
+	b.extractSignals(root)
+	c.signals = b.signals
+	if errs := c.check(root); errs.IsError() {
+		return nil, errs
+	}
+
+	translate(root)
+	data, _ := root.Render()
+	c.gtype = c.register(data, root.IDs())
+
+	composites.Lock()
+	composites.types[c.gtype] = c
+	composites.Unlock()
+	externglib.RegisterGValueMarshalers([]externglib.TypeMarshaler{{T: c.gtype, F: c.marshal}})
+	return c, nil
+}
+
+// Name returns the class name.
+func (c *Composite) Name() string { return c.name }
+
+// Type returns the registered GType.
+func (c *Composite) Type() externglib.Type { return c.gtype }
+
+// New creates an instance and returns its go value, a pointer to the struct.
+func (c *Composite) New() interface{} {
+	ptr := C.g_object_new_with_properties(C.GType(c.gtype), 0, nil, nil)
+	return externglib.Take(unsafe.Pointer(ptr)).Cast() // Already sunk by init.
+}
+
+// check verifies the struct fields and methods match the template.
+func (c *Composite) check(root *uixml.Node) (errs grun.Errors) {
+	ids := root.IDs()
+	for i := 1; i < c.typ.NumField(); i++ {
+		field := c.typ.Field(i)
+		id := field.Tag.Get(TagName)
+		if id != "" && id != "-" && !contains(ids, id) {
+			errs.Append(&NotFoundError{ID: id, Type: typeName(field.Type)})
+		}
+	}
+
+	methods := reflect.PtrTo(c.typ)
+	for _, sig := range c.signals {
+		if _, ok := methods.MethodByName(sig.handler); !ok {
+			errs.Append(fmt.Errorf(FmtErrHandlerNotFound, sig.handler, sig.name, sig.id))
+		}
+	}
+	return errs
+}
+
+// register registers the GType with the rendered template and its ids.
+func (c *Composite) register(template []byte, ids []string) externglib.Type {
+	class := (*C.CompositeClass)(C.calloc(1, C.sizeof_CompositeClass)) // Kept forever.
+	class.template = C.g_bytes_new_take(C.gpointer(C.CBytes(template)), C.gsize(len(template)))
+	class.css_name = C.CString(strings.ToLower(c.name))
+	class.n_children = C.int(len(ids))
+	if len(ids) > 0 {
+		children := (*[1 << 20]*C.char)(C.malloc(C.size_t(len(ids)) * C.size_t(unsafe.Sizeof((*C.char)(nil)))))
+		for i, id := range ids {
+			children[i] = C.CString(id)
+		}
+		class.children = &children[0]
+	}
+
+	name := C.CString(c.name)
+	defer C.free(unsafe.Pointer(name))
+	return externglib.Type(C.composite_register(C.GType(c.parent), name, class))
+}
+
+// marshal returns the go value of the instance, or the parent wrapper while
+// the instance is initialized.
+func (c *Composite) marshal(p uintptr) (interface{}, error) {
+	ptr := uintptr(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))))
+	composites.Lock()
+	v, ok := composites.instances[ptr]
+	composites.Unlock()
+	if ok {
+		return v, nil
+	}
+	return c.parentValue(ptr), nil
+}
+
+// parentValue returns the instance wrapped as the parent class, like *gtk.Box.
+func (c *Composite) parentValue(ptr uintptr) interface{} {
+	value := externglib.InitValue(c.parent)
+	value.SetInstance(ptr)
+	return value.GoValue()
+}
+
+//
+//------------------------------------------------------------------[ INSTANCE ]--
+
+// buildhelpCompositeInit creates the go value of a new instance, after the
+// template is built.
+//
+//export buildhelpCompositeInit
+func buildhelpCompositeInit(instance *C.GObject, gtype C.GType) {
+	composites.Lock()
+	c := composites.types[externglib.Type(gtype)]
+	composites.Unlock()
+	if c != nil {
+		c.init(unsafe.Pointer(instance))
+	}
+}
+
+// init binds the template children and signals to a new go value.
+func (c *Composite) init(instance unsafe.Pointer) {
+	obj := externglib.Take(instance)
+	ptr := reflect.New(c.typ)
+	b := &BuildHelp{signals: c.signals}
+	b.lookup = func(id string) *externglib.Object {
+		if id == "" {
+			return obj
+		}
+		cstr := C.CString(id)
+		defer C.free(unsafe.Pointer(cstr))
+		child := C.composite_child((*C.GObject)(instance), C.GType(c.gtype), cstr)
+		if child == nil {
+			return nil
+		}
+		return externglib.Take(unsafe.Pointer(child))
+	}
+
+	if !setField(ptr.Elem().Field(0), c.parentValue(uintptr(instance))) {
+		b.errors.Append(fmt.Errorf(FmtErrCompositeField, c.typ))
+	}
+	b.Bind(ptr.Interface())
+	handlers := map[string]interface{}{} // Only handlers, other methods are not unused.
+	for _, sig := range c.signals {
+		if fn := ptr.MethodByName(sig.handler); fn.IsValid() {
+			handlers[sig.handler] = fn.Interface()
+		}
+	}
+	b.ConnectSignals(handlers)
+	if b.errors.IsError() {
+		log.Printf(FmtErrCompositeInit, c.name, b.errors)
+	}
+
+	composites.Lock()
+	composites.instances[uintptr(instance)] = ptr.Interface()
+	composites.Unlock()
+	obj.Connect("destroy", func() {
+		composites.Lock()
+		delete(composites.instances, uintptr(instance))
+		composites.Unlock()
+	})
+}
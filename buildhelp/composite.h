@@ -0,0 +1,12 @@
+#include <glib-object.h>
+
+// CompositeClass holds the class data of a composite widget, kept forever.
+typedef struct {
+	GBytes *template;
+	char *css_name;
+	char **children;
+	int n_children;
+} CompositeClass;
+
+GType composite_register(GType parent, const char *name, CompositeClass *c);
+GObject *composite_child(GObject *instance, GType gtype, const char *name);
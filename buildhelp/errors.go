@@ -0,0 +1,75 @@
+package buildhelp
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+// FmtErrParse formats the interface parsing errors.
+var FmtErrParse = "%s: %s" // Format: position, message
+
+//
+//------------------------------------------------------------------[ ERRORS ]--
+
+// NotFoundError is the error of an object id missing in the builder.
+type NotFoundError struct {
+	ID   string // Object id.
+	Type string // Requested type, like Button.
+}
+
+func (e *NotFoundError) Error() string { return fmt.Sprintf(FmtErrNotFound, e.ID, e.Type) }
+
+// BadTypeError is the error of an object with another type than requested.
+type BadTypeError struct {
+	ID    string      // Object id.
+	Type  string      // Requested type, like Button.
+	Found interface{} // Object found, like *gtk.Label.
+}
+
+func (e *BadTypeError) Error() string { return fmt.Sprintf(FmtErrBadType, e.ID, e.Type) }
+
+// ParseError is the error of an invalid interface: malformed XML, unknown
+// class or property...
+type ParseError struct {
+	Pos uixml.Pos // Position in the source file.
+	Msg string    // Error message, without position.
+	Err error     // Error returned by the XML parser or gtk.Builder (a GError).
+}
+
+func (e *ParseError) Error() string { return fmt.Sprintf(FmtErrParse, e.Pos, e.Msg) }
+
+// Unwrap returns the parser error.
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// reGError matches gtk.Builder error positions: <input>:12:3 message.
+var reGError = regexp.MustCompile(`^(?s)(.*?):(\d+):(\d+):? (.*)$`)
+
+// parseError converts a parser or gtk.Builder error to *ParseError.
+//
+// The lines of the interface given to gtk.Builder are converted to source
+// positions if provided.
+func parseError(e error, file string, lines []uixml.Pos) error {
+	if pe, ok := e.(*ParseError); ok {
+		return pe
+	}
+	var se *uixml.SyntaxError
+	if errors.As(e, &se) {
+		return &ParseError{Pos: se.Pos, Msg: se.Msg, Err: e}
+	}
+
+	m := reGError.FindStringSubmatch(e.Error())
+	if m == nil {
+		return &ParseError{Pos: uixml.Pos{File: file}, Msg: e.Error(), Err: e}
+	}
+	line, _ := strconv.Atoi(m[2])
+	col, _ := strconv.Atoi(m[3])
+	pos := uixml.Pos{File: file, Line: line, Col: col}
+	if lines != nil {
+		pos = uixml.LinePos(lines, line) // The column is lost in the rewrite.
+	}
+	return &ParseError{Pos: pos, Msg: m[4], Err: e}
+}
@@ -5,10 +5,10 @@ import (
 	"fmt"
 	"time"
 
-	externglib "github.com/diamondburned/gotk4/pkg/core/glib"
 	"github.com/diamondburned/gotk4/pkg/gtk/v4"
 	"github.com/gtkool4/grun"
 	"github.com/gtkool4/gtkelp/buildhelp"
+	"github.com/gtkool4/gtkelp/gtkest"
 	"github.com/gtkool4/gtkelp/gtknew"
 )
 
@@ -26,12 +26,13 @@ var uiBasic string
 //   Acts as a io.Stringer to interact with the go world
 //
 type CustomWidget struct {
-	gtk.Box // Main container is first level. Act as (at least) a gtk.Widgetter.
-	cut     *gtk.Button
-	copy    *gtk.Button
-	paste   *gtk.Button
-	quit    *gtk.Button
-	text    *gtk.TextView
+	gtk.Box `gtk:"mainbox"` // Main container is first level. Act as (at least) a gtk.Widgetter.
+	cut     *gtk.Button     `gtk:"cut"`
+	copy    *gtk.Button     `gtk:"copy"`
+	paste   *gtk.Button     `gtk:"paste"`
+	quit    *gtk.Button     `gtk:"quit"`
+	text    *gtk.TextView   `gtk:"text"`
+	closer  interface{ Close() }
 }
 
 func NewCustomWidget(closer interface{ Close() }, b *buildhelp.BuildHelp) (*CustomWidget, grun.Errors) {
@@ -41,29 +42,24 @@ func NewCustomWidget(closer interface{ Close() }, b *buildhelp.BuildHelp) (*Cust
 	// callbacks to prevent the GC from freeing the associated function.
 	// Remember that otherwise it will be seen as unused on the go side.
 	//
-	w := &CustomWidget{
-		Box:   *b.Box("mainbox"),
-		cut:   b.Button("cut"),
-		copy:  b.Button("copy"),
-		paste: b.Button("paste"),
-		quit:  b.Button("quit"),
-		text:  b.TextView("text"),
-	}
+	// Bind fills all fields tagged with the object id.
+	//
+	w := &CustomWidget{closer: closer}
+	b.Bind(w)
 
 	// Get other widgets from the builder.
 	menu := b.HeaderBar("menubar")
 
+	// Connect signals declared in the interface to the methods of the same name.
+	b.ConnectSignals(w)
+
 	// Check builder errors.
 	if b.Errors().IsError() {
 		return nil, b.Errors()
 	}
 
-	// Apply last settings and connect events callbacks.
+	// Apply last settings.
 	menu.SetShowTitleButtons(true)
-	w.cut.Connect("activate", w.Cut)
-	w.copy.Connect("activate", w.Copy)
-	w.paste.Connect("activate", w.Paste)
-	w.quit.Connect("activate", func() { fmt.Println("closed"); closer.Close() })
 	return w, nil
 }
 
@@ -72,6 +68,7 @@ func NewCustomWidget(closer interface{ Close() }, b *buildhelp.BuildHelp) (*Cust
 func (w *CustomWidget) Copy()  { w.text.Buffer().CopyClipboard(w.text.Clipboard()) }             // Copy copies the selected text.
 func (w *CustomWidget) Cut()   { w.text.Buffer().CutClipboard(w.text.Clipboard(), true) }        // Cut cuts the selected text.
 func (w *CustomWidget) Paste() { w.text.Buffer().PasteClipboard(w.text.Clipboard(), nil, true) } // Paste pastes the buffer at cursor position.
+func (w *CustomWidget) Quit()  { fmt.Println("closed"); w.closer.Close() }                       // Quit closes the window.
 
 // String returns the content of the text buffer.
 func (w *CustomWidget) String() string {
@@ -115,30 +112,33 @@ func Example() {
 
 func (w *CustomWidget) testButtons() {
 	testStr := "Hello GTK4!"
+	wait := func(want string) { // copy/paste are async calls so we're resyncing after them.
+		if e := gtkest.Wait(time.Second, func() error { return gtkest.Equal(w.String(), want) }); e != nil {
+			fmt.Println(e)
+		}
+	}
+
 	fmt.Println("start") // start
 	fmt.Println(w)       // empty
-	w.text.Buffer().InsertAtCursor(testStr, -1)
+	gtkest.Type(w.text, testStr)
 	fmt.Println(w) // testStr
 	start, end := w.text.Buffer().Bounds()
 	w.text.Buffer().SelectRange(&start, &end)
-	w.cut.Activate()
-	externglib.IdleAdd(func() { // copy/paste are async calls so we're resyncing after them.
-		fmt.Println(w) // empty
-		w.paste.Activate()
-		externglib.IdleAdd(func() {
-			fmt.Println(w) // testStr
-			start, end = w.text.Buffer().Bounds()
-			start.ForwardChars(5)
-			w.text.Buffer().SelectRange(&start, &end)
-			w.copy.Activate()
-			w.paste.Activate()
-			w.paste.Activate()
-			externglib.IdleAdd(func() {
-				fmt.Println(w)    // Hello GTK4! GTK4!
-				w.quit.Activate() // test the last button
-			})
-		})
-	})
+	gtkest.Click(w.cut)
+	wait("")
+	fmt.Println(w) // empty
+	gtkest.Click(w.paste)
+	wait(testStr)
+	fmt.Println(w) // testStr
+	start, end = w.text.Buffer().Bounds()
+	start.ForwardChars(5)
+	w.text.Buffer().SelectRange(&start, &end)
+	gtkest.Click(w.copy)
+	gtkest.Click(w.paste)
+	gtkest.Click(w.paste)
+	wait("Hello GTK4! GTK4!")
+	fmt.Println(w)       // Hello GTK4! GTK4!
+	gtkest.Click(w.quit) // test the last button
 }
 
 // func ExampleNewFromFile() {
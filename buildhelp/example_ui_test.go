@@ -0,0 +1,30 @@
+// Code generated by uigen from example_test.ui. DO NOT EDIT.
+
+package buildhelp_test
+
+import (
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+	"github.com/gtkool4/grun"
+	"github.com/gtkool4/gtkelp/buildhelp"
+)
+
+// ExampleUI gives typed access to the objects of example_test.ui.
+type ExampleUI struct {
+	Mainbox *gtk.Box       `gtk:"mainbox"` // GtkBox
+	Menubar *gtk.HeaderBar `gtk:"menubar"` // GtkHeaderBar
+	Cut     *gtk.Button    `gtk:"cut"`     // GtkButton
+	Copy    *gtk.Button    `gtk:"copy"`    // GtkButton
+	Paste   *gtk.Button    `gtk:"paste"`   // GtkButton
+	Sep     *gtk.Separator `gtk:"sep"`     // GtkSeparator
+	Quit    *gtk.Button    `gtk:"quit"`    // GtkButton
+	Text    *gtk.TextView  `gtk:"text"`    // GtkTextView
+}
+
+// NewExampleUI loads the objects of example_test.ui from the builder.
+func NewExampleUI(b *buildhelp.BuildHelp) (*ExampleUI, grun.Errors) {
+	ui := &ExampleUI{}
+	return ui, ui.Load(b)
+}
+
+// Load fills the struct with the objects of example_test.ui from the builder.
+func (ui *ExampleUI) Load(b *buildhelp.BuildHelp) grun.Errors { return b.Bind(ui) }
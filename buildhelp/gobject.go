@@ -0,0 +1,83 @@
+package buildhelp
+
+// #cgo pkg-config: gtk4
+// #include <stdlib.h>
+// #include <gtk/gtk.h>
+//
+// static gboolean is_file_type(GType gtype) {
+// 	return g_type_is_a(gtype, G_TYPE_FILE) || g_type_is_a(gtype, G_TYPE_ICON) || g_type_is_a(gtype, GDK_TYPE_PAINTABLE);
+// }
+//
+// static gboolean is_image(GType gtype) { return g_type_is_a(gtype, GTK_TYPE_IMAGE); }
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+
+	externglib "github.com/diamondburned/gotk4/pkg/core/glib"
+)
+
+// signalParams returns the number of parameters of the detailed signal for the
+// object, without the instance, or -1 if the object has no such signal.
+func signalParams(obj *externglib.Object, detailedSignal string) int {
+	cstr := C.CString(detailedSignal)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var id C.guint
+	var detail C.GQuark
+	found := C.g_signal_parse_name((*C.gchar)(unsafe.Pointer(cstr)), C.GType(obj.TypeFromInstance()), &id, &detail, C.gboolean(0))
+	runtime.KeepAlive(obj)
+	if found == 0 {
+		return -1
+	}
+
+	var query C.GSignalQuery
+	C.g_signal_query(id, &query)
+	return int(query.n_params)
+}
+
+// isObjectProperty returns true if the property of the class holds an object,
+// so its value in the interface is an object id.
+func isObjectProperty(class, property string) bool {
+	cclass := C.CString(class)
+	defer C.free(unsafe.Pointer(cclass))
+	gtype := C.g_type_from_name((*C.gchar)(unsafe.Pointer(cclass)))
+	switch C.g_type_fundamental(propertyType(gtype, property)) {
+	case C.G_TYPE_OBJECT, C.G_TYPE_INTERFACE:
+		return true
+	}
+	return false
+}
+
+// isFileProperty returns true if the property of the class can be loaded from
+// a file by gtk.Builder: a GFile, a GIcon, a GdkPaintable, or the file name of
+// a GtkImage. The class is looked up by the builder, which registers it.
+func (b *BuildHelp) isFileProperty(class, property string) bool {
+	gtype := C.GType(b.TypeFromName(class))
+	value := propertyType(gtype, property)
+	switch {
+	case value == 0:
+		return false
+	case C.is_file_type(value) != 0:
+		return true
+	}
+	return value == C.G_TYPE_STRING && property == "file" && C.is_image(gtype) != 0
+}
+
+// propertyType returns the value type of the property of the object class, or
+// 0 if unknown.
+func propertyType(gtype C.GType, property string) C.GType {
+	if gtype == 0 || C.g_type_fundamental(gtype) != C.G_TYPE_OBJECT {
+		return 0
+	}
+	klass := C.g_type_class_ref(gtype)
+	defer C.g_type_class_unref(klass)
+	cname := C.CString(property)
+	defer C.free(unsafe.Pointer(cname))
+	spec := C.g_object_class_find_property((*C.GObjectClass)(klass), (*C.gchar)(unsafe.Pointer(cname)))
+	if spec == nil {
+		return 0
+	}
+	return spec.value_type
+}
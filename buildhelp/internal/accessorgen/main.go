@@ -0,0 +1,184 @@
+// Command accessorgen generates the BuildHelp typed accessors.
+//
+// The list of classes is read from the gotk4 gtk package, generated from the
+// GTK4 GIR metadata: every GObject class registered with a marshaler gets an
+// accessor, except abstract classes and interfaces which can't be built by
+// gtk.Builder (gotk4 describes them with an "abstract methods" interface).
+//
+// Classes missing from the gotk4 version in go.mod have no accessor. The pinned
+// version has no StringList: fetch it with GetObject until gotk4 is updated,
+// then run accessorgen again.
+//
+// Run with go generate in the buildhelp package to sync with gotk4.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// GtkPackage is the gotk4 package scanned for classes.
+var GtkPackage = "github.com/diamondburned/gotk4/pkg/gtk/v4"
+
+// Excluded lists classes without accessor, with their subclasses.
+var Excluded = map[string]bool{
+	"Builder":    true, // Name used by the BuildHelp embedded field.
+	"Expression": true, // Fundamental type, not a GObject.
+}
+
+var reAbstract = regexp.MustCompile(`^\w+ describes (\w+)'s abstract methods`)
+
+func main() {
+	output := flag.String("o", "accessors.go", "output file")
+	dir := flag.String("dir", "", "gotk4 gtk package directory (default: from go list)")
+	flag.Parse()
+
+	if *dir == "" {
+		out, e := exec.Command("go", "list", "-f", "{{.Dir}}", GtkPackage).Output()
+		if e != nil {
+			fail(fmt.Errorf("go list %s: %w", GtkPackage, e))
+		}
+		*dir = strings.TrimSpace(string(out))
+	}
+
+	classes, e := Classes(*dir)
+	if e != nil {
+		fail(e)
+	}
+	src, e := Generate(classes)
+	if e != nil {
+		fail(e)
+	}
+	if e := os.WriteFile(*output, src, 0644); e != nil {
+		fail(e)
+	}
+}
+
+func fail(e error) {
+	fmt.Fprintln(os.Stderr, "accessorgen:", e)
+	os.Exit(1)
+}
+
+// Classes returns the sorted list of buildable classes of the gotk4 package.
+func Classes(dir string) ([]string, error) {
+	fset := token.NewFileSet()
+	pkgs, e := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if e != nil {
+		return nil, e
+	}
+
+	marshaled := map[string]bool{} // Registered marshaler functions.
+	wraps := map[string]string{}   // Marshaler function: wrapped type.
+	abstract := map[string]bool{}
+	parents := map[string][]string{} // Type: embedded types.
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.KeyValueExpr: // {T: ..., F: marshalButtonner}
+					if key, ok := n.Key.(*ast.Ident); ok && key.Name == "F" {
+						if fn, ok := n.Value.(*ast.Ident); ok {
+							marshaled[fn.Name] = true
+						}
+					}
+
+				case *ast.FuncDecl: // return wrapButton(obj), nil
+					if n.Recv == nil && n.Body != nil && strings.HasPrefix(n.Name.Name, "marshal") {
+						if typ := wrapped(n.Body); typ != "" {
+							wraps[n.Name.Name] = typ
+						}
+					}
+
+				case *ast.GenDecl: // Widgetter describes Widget's abstract methods.
+					if m := reAbstract.FindStringSubmatch(n.Doc.Text()); m != nil {
+						abstract[m[1]] = true
+					}
+
+				case *ast.TypeSpec: // type Button struct { Widget; ... }
+					if st, ok := n.Type.(*ast.StructType); ok {
+						for _, field := range st.Fields.List {
+							if id, ok := field.Type.(*ast.Ident); ok && len(field.Names) == 0 {
+								parents[n.Name.Name] = append(parents[n.Name.Name], id.Name)
+							}
+						}
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	var list []string
+	for fn, typ := range wraps {
+		if marshaled[fn] && !abstract[typ] && !excluded(parents, typ) && ast.IsExported(typ) {
+			list = append(list, typ)
+		}
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+// excluded returns true if the type or one of its parents is excluded.
+func excluded(parents map[string][]string, typ string) bool {
+	if Excluded[typ] {
+		return true
+	}
+	for _, parent := range parents[typ] {
+		if excluded(parents, parent) {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapped returns the type returned by the marshaler body: wrapX(obj) is X.
+func wrapped(body *ast.BlockStmt) (typ string) {
+	for _, stmt := range body.List {
+		ret, ok := stmt.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) == 0 {
+			continue
+		}
+		if call, ok := ret.Results[0].(*ast.CallExpr); ok {
+			if fn, ok := call.Fun.(*ast.Ident); ok && strings.HasPrefix(fn.Name, "wrap") {
+				typ = strings.TrimPrefix(fn.Name, "wrap")
+			}
+		}
+	}
+	return typ
+}
+
+// Generate returns the formatted accessors source.
+func Generate(classes []string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`// Code generated by accessorgen from gotk4 gtk/v4. DO NOT EDIT.
+
+package buildhelp
+
+import "github.com/diamondburned/gotk4/pkg/gtk/v4"
+
+//
+//-----------------------------------------------------------------[ OBJECTS ]--
+`)
+	for _, name := range classes {
+		fmt.Fprintf(&buf, `
+// %[1]s get the named object as %[1]s.
+func (b *BuildHelp) %[1]s(name string) (w *gtk.%[1]s) {
+	b.getO("%[1]s", name, func(o interface{}) (ok bool) { w, ok = o.(*gtk.%[1]s); return })
+	return w
+}
+`, name)
+	}
+	return format.Source(buf.Bytes())
+}
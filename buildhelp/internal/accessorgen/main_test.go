@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestClasses(t *testing.T) {
+	out, e := exec.Command("go", "list", "-f", "{{.Dir}}", GtkPackage).Output()
+	if e != nil {
+		t.Skip("gotk4 not found:", e)
+	}
+	classes, e := Classes(strings.TrimSpace(string(out)))
+	if e != nil {
+		t.Fatal("classes error:", e)
+	}
+
+	found := map[string]bool{}
+	for _, class := range classes {
+		found[class] = true
+	}
+	for class, expected := range map[string]bool{
+		"Button":             true,
+		"ListView":           true,
+		"EventControllerKey": true,
+		"Widget":             false, // Abstract.
+		"EventController":    false, // Abstract.
+		"Editable":           false, // Interface.
+		"PropertyExpression": false, // Not a GObject.
+		"Builder":            false, // Excluded.
+	} {
+		if found[class] != expected {
+			t.Errorf("class %s found should be %v", class, expected)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	src, e := Generate([]string{"Button"})
+	if e != nil {
+		t.Fatal("generate error:", e)
+	}
+	if !strings.Contains(string(src), "func (b *BuildHelp) Button(name string) (w *gtk.Button) {") {
+		t.Errorf("generated source should have the Button accessor:\n%s", src)
+	}
+}
@@ -0,0 +1,281 @@
+package buildhelp
+
+// #cgo pkg-config: gtk4
+// #include <stdlib.h>
+// #include <gtk/gtk.h>
+//
+// static const char *overlay_class(GObject *obj) { return G_OBJECT_TYPE_NAME(obj); }
+//
+// static gboolean overlay_writable(GObject *obj, const char *name) {
+// 	GParamSpec *spec = g_object_class_find_property(G_OBJECT_GET_CLASS(obj), name);
+// 	return spec && (spec->flags & G_PARAM_WRITABLE) && !(spec->flags & G_PARAM_CONSTRUCT_ONLY);
+// }
+//
+// // overlay_set converts the text to the property type like gtk.Builder does
+// // for interfaces, and sets the property. Returns the error message or NULL,
+// // free with g_free.
+// static char *overlay_set(GtkBuilder *builder, GObject *obj, const char *name, const char *str) {
+// 	GParamSpec *spec = g_object_class_find_property(G_OBJECT_GET_CLASS(obj), name);
+// 	GValue value = G_VALUE_INIT;
+// 	GError *error = NULL;
+// 	if (!gtk_builder_value_from_string(builder, spec, str, &value, &error)) {
+// 		char *msg = g_strdup(error->message);
+// 		g_error_free(error);
+// 		return msg;
+// 	}
+// 	g_object_set_property(obj, name, &value);
+// 	g_value_unset(&value);
+// 	return NULL;
+// }
+import "C"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"unsafe"
+
+	"github.com/gtkool4/grun"
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+// Errors formating.
+var (
+	FmtErrOverlay         = "%s: overlay %s: %s"             // Format: file, key, message
+	FmtErrOverlayID       = "no object %s"                   // Format: id
+	FmtErrOverlayProperty = "%s has no writable property %s" // Format: class, property
+	FmtErrOverlayValue    = "unsupported value %v"           // Format: JSON value
+	TxtErrOverlayKey      = "key is not id.property"
+	TxtErrOverlayRoot     = "overlay must be a JSON object"
+	TxtErrOverlayTrailing = "data after the overlay object"
+)
+
+//
+//-----------------------------------------------------------------[ OVERLAY ]--
+
+// Overlay is a set of property values applied on built objects, by
+// "id.property" key. It lets the same interface vary texts, icons, CSS classes
+// or visibility, for branding or feature flags.
+//
+// Overlays are JSON objects of values by key. A key without property groups
+// the values of an id:
+//
+//   {
+//     "title.label": "Acme Editor",
+//     "logo.icon-name": "acme-logo",
+//     "toolbar": {"visible": false, "css-classes": ["flat", "acme"]}
+//   }
+//
+// Values are converted to the property type like gtk.Builder does: enum nicks,
+// flags separated by |, object ids... Lists are string arrays, like CSS classes.
+type Overlay struct {
+	keys   []string                // In declaration order.
+	values map[string]overlayValue // By key.
+}
+
+type overlayValue struct {
+	text string
+	file string // Declared in, for errors.
+}
+
+// NewOverlay creates an empty overlay.
+func NewOverlay() *Overlay {
+	return &Overlay{values: map[string]overlayValue{}}
+}
+
+// ParseOverlay parses the JSON overlay, or returns a *ParseError. The file
+// name is used for errors.
+func ParseOverlay(data []byte, file string) (*Overlay, error) {
+	o := NewOverlay()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	e := o.parse(dec, file, "")
+	offset := dec.InputOffset()
+	switch {
+	case e == io.EOF:
+		e = errors.New(TxtErrOverlayRoot)
+	case e == nil:
+		rest := data[offset:]
+		offset += int64(len(rest) - len(bytes.TrimLeft(rest, " \t\r\n")))
+		if _, e = dec.Token(); e == io.EOF {
+			return o, nil
+		}
+		e = errors.New(TxtErrOverlayTrailing)
+	}
+
+	var se *json.SyntaxError
+	if errors.As(e, &se) {
+		offset = se.Offset - 1 // Offset is after the error.
+	}
+	return nil, &ParseError{Pos: uixml.OffsetPos(data, file, offset), Msg: e.Error(), Err: e}
+}
+
+// ReadOverlays reads and layers the overlay files: values of later files win.
+func ReadOverlays(files ...string) (*Overlay, error) {
+	o := NewOverlay()
+	for _, file := range files {
+		data, e := os.ReadFile(file)
+		if e != nil {
+			return nil, e
+		}
+		layer, e := ParseOverlay(data, file)
+		if e != nil {
+			return nil, e
+		}
+		o.Add(layer)
+	}
+	return o, nil
+}
+
+// Add layers overlays on this one: their values win.
+func (o *Overlay) Add(layers ...*Overlay) *Overlay {
+	for _, layer := range layers {
+		for _, key := range layer.keys {
+			o.set(key, layer.values[key])
+		}
+	}
+	return o
+}
+
+// Set sets the value of the key, as text converted to the property type.
+func (o *Overlay) Set(key, value string) *Overlay {
+	o.set(key, overlayValue{text: value})
+	return o
+}
+
+// Get returns the value of the key, and whether it is set.
+func (o *Overlay) Get(key string) (string, bool) {
+	v, ok := o.values[key]
+	return v.text, ok
+}
+
+// Keys returns the keys in declaration order.
+func (o *Overlay) Keys() []string { return append([]string(nil), o.keys...) }
+
+func (o *Overlay) set(key string, v overlayValue) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = v
+}
+
+// parse adds the values of the JSON object, with keys after the prefix.
+func (o *Overlay) parse(dec *json.Decoder, file, prefix string) error {
+	if tok, e := dec.Token(); e != nil {
+		return e
+	} else if tok != json.Delim('{') {
+		return errors.New(TxtErrOverlayRoot)
+	}
+	return o.parseMembers(dec, file, prefix)
+}
+
+// parseMembers adds the values of the JSON object after its opening brace.
+func (o *Overlay) parseMembers(dec *json.Decoder, file, prefix string) error {
+	for dec.More() {
+		tok, e := dec.Token()
+		if e != nil {
+			return e
+		}
+		key := prefix + tok.(string)
+		if tok, e = dec.Token(); e != nil {
+			return e
+		}
+		if prefix == "" && tok == json.Delim('{') { // Values of an id.
+			if e := o.parseMembers(dec, file, key+"."); e != nil {
+				return e
+			}
+			continue
+		}
+		text, e := overlayText(dec, tok)
+		if e != nil {
+			return fmt.Errorf("%s: %w", key, e)
+		}
+		o.set(key, overlayValue{text: text, file: file})
+	}
+	_, e := dec.Token() // }
+	return e
+}
+
+// overlayText reads the JSON value starting with the token as text for
+// gtk.Builder: lists of strings are joined by newlines.
+func overlayText(dec *json.Decoder, tok json.Token) (string, error) {
+	switch tok := tok.(type) {
+	case string:
+		return tok, nil
+	case json.Number:
+		return tok.String(), nil
+	case bool:
+		return fmt.Sprint(tok), nil
+	}
+	if tok != json.Delim('[') {
+		return "", fmt.Errorf(FmtErrOverlayValue, tok)
+	}
+	var list []string
+	for dec.More() {
+		item, e := dec.Token()
+		if e != nil {
+			return "", e
+		}
+		str, ok := item.(string)
+		if !ok {
+			return "", fmt.Errorf(FmtErrOverlayValue, item)
+		}
+		list = append(list, str)
+	}
+	_, e := dec.Token() // ]
+	return strings.Join(list, "\n"), e
+}
+
+// ApplyOverlay sets the property values of the overlays on the builder
+// objects. Overlays are layered: values of later ones win.
+//
+// Every unknown id, property or invalid value is appended to the builder
+// errors, which are returned for convenience.
+func (b *BuildHelp) ApplyOverlay(overlays ...*Overlay) grun.Errors {
+	o := NewOverlay().Add(overlays...)
+	for _, key := range o.keys {
+		v := o.values[key]
+		if e := b.applyValue(key, v.text); e != nil {
+			file := v.file
+			if file == "" {
+				file = "<overlay>"
+			}
+			b.errors.Append(fmt.Errorf(FmtErrOverlay, file, key, e))
+		}
+	}
+	return b.errors
+}
+
+func (b *BuildHelp) applyValue(key, value string) error {
+	dot := strings.LastIndexByte(key, '.')
+	if dot <= 0 || dot == len(key)-1 {
+		return errors.New(TxtErrOverlayKey)
+	}
+	id, property := key[:dot], key[dot+1:]
+	obj := b.object(id)
+	if obj == nil {
+		return fmt.Errorf(FmtErrOverlayID, id)
+	}
+	defer runtime.KeepAlive(obj)
+	native := (*C.GObject)(unsafe.Pointer(obj.Native()))
+
+	cname := C.CString(property)
+	defer C.free(unsafe.Pointer(cname))
+	if C.overlay_writable(native, cname) == 0 {
+		return fmt.Errorf(FmtErrOverlayProperty, C.GoString(C.overlay_class(native)), property)
+	}
+
+	cvalue := C.CString(value)
+	defer C.free(unsafe.Pointer(cvalue))
+	msg := C.overlay_set((*C.GtkBuilder)(unsafe.Pointer(b.Native())), native, cname, cvalue)
+	if msg != nil {
+		defer C.g_free(C.gpointer(unsafe.Pointer(msg)))
+		return errors.New(C.GoString(msg))
+	}
+	return nil
+}
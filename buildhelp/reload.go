@@ -0,0 +1,215 @@
+package buildhelp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gtkool4/grun"
+	"github.com/gtkool4/gtkelp/gtknew"
+
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+)
+
+// ReloadInterval is the delay between two checks of the watched files.
+var ReloadInterval = 300 * time.Millisecond
+
+//
+//-----------------------------------------------------------------[ RELOADER ]--
+
+// Reloader is a development helper that rebuilds the interface when its file
+// or an included fragment changes, and swaps the new root widget in place.
+//
+// The build function is called again on each change, so it can bind fields and
+// connect signals as usual. When the new file is invalid, the previous tree is
+// kept and the errors are shown above it until the next valid change.
+//
+// The Reloader is a widget: pack it in the window as the root widget would be.
+//
+//   gapp.Run(func() gtk.Widgetter {
+//     return buildhelp.NewReloader("main.ui", func(b *buildhelp.BuildHelp) (gtk.Widgetter, grun.Errors) {
+//       return NewCustomWidget(gapp.Win, b)
+//     })
+//   })
+//
+type Reloader struct {
+	gtk.Box
+	file   string
+	build  func(*BuildHelp) (gtk.Widgetter, grun.Errors)
+	errors *gtk.Label
+	root   gtk.Widgetter
+	b      *BuildHelp
+	notify func(grun.Errors) // Called after each reload.
+
+	mu    sync.Mutex           // Protects files and stop.
+	files map[string]time.Time // Modification time of watched files.
+	stop  chan struct{}
+}
+
+// NewReloader creates a Reloader for the interface file, and starts watching it.
+//
+// The first build is done immediately: check Errors to see if it failed.
+func NewReloader(file string, build func(b *BuildHelp) (gtk.Widgetter, grun.Errors)) *Reloader {
+	r := &Reloader{
+		Box:    *gtk.NewBox(gtk.OrientationVertical, 0),
+		file:   file,
+		build:  build,
+		errors: gtk.NewLabel(""),
+		stop:   make(chan struct{}),
+	}
+	r.errors.AddCSSClass("error")
+	r.errors.SetSelectable(true)
+	r.errors.SetWrap(true)
+	r.errors.SetXAlign(0)
+	r.errors.Hide()
+	r.Append(r.errors)
+
+	r.Reload()
+	go r.watch()
+	return r
+}
+
+// BuildHelp returns the builder of the current tree, or nil if none succeeded.
+func (r *Reloader) BuildHelp() *BuildHelp { return r.b }
+
+// Root returns the current root widget, or nil if none succeeded.
+func (r *Reloader) Root() gtk.Widgetter { return r.root }
+
+// Errors returns the errors of the last reload.
+func (r *Reloader) Errors() string {
+	if !r.errors.IsVisible() {
+		return ""
+	}
+	return r.errors.Text()
+}
+
+// OnReload sets the function called after each reload, with its errors.
+func (r *Reloader) OnReload(call func(errs grun.Errors)) { r.notify = call }
+
+// Reload rebuilds the interface now. Must be called from the gtk main loop.
+//
+// Returns the errors of the new tree, which is only swapped if valid.
+func (r *Reloader) Reload() grun.Errors {
+	errs := r.reload()
+	if r.notify != nil {
+		r.notify(errs)
+	}
+	return errs
+}
+
+func (r *Reloader) reload() grun.Errors {
+	b, e := ParseFile(r.file)
+	if e != nil {
+		files := r.watched()
+		var parseErr *ParseError
+		if errors.As(e, &parseErr) && parseErr.Pos.File != "" {
+			files = append(files, parseErr.Pos.File) // A new include may be broken.
+		}
+		r.setFiles(files)
+		return r.fail(grun.Errors{e})
+	}
+
+	r.setFiles(b.files)
+	w, errs := r.build(b)
+	if errs.IsError() || w == nil {
+		b.Close()
+		return r.fail(errs)
+	}
+
+	if r.root != nil {
+		r.Remove(r.root)
+	}
+	if r.b != nil {
+		r.b.Close()
+	}
+	r.root, r.b = w, b
+	r.Append(w)
+	r.errors.Hide()
+	return nil
+}
+
+// Stop stops watching files. The current tree is kept.
+func (r *Reloader) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	select {
+	case <-r.stop:
+	default:
+		close(r.stop)
+	}
+}
+
+// fail shows the errors above the previous tree.
+func (r *Reloader) fail(errs grun.Errors) grun.Errors {
+	r.errors.SetText(fmt.Sprintf(grun.FmtErrLabel, errs))
+	r.errors.Show()
+	return errs
+}
+
+//
+//-------------------------------------------------------------------[ WATCH ]--
+
+// watch checks the files modification time, and reloads on the gtk main loop
+// when one changed.
+func (r *Reloader) watch() {
+	ticker := time.NewTicker(ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		}
+
+		if r.changed() {
+			gtknew.Idle(func() { r.Reload() })
+		}
+	}
+}
+
+// changed returns true if a watched file was modified since the last check.
+func (r *Reloader) changed() (changed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for file, last := range r.files {
+		if mod := modTime(file); !mod.Equal(last) {
+			r.files[file] = mod
+			changed = true
+		}
+	}
+	return changed
+}
+
+// setFiles replaces the list of watched files.
+func (r *Reloader) setFiles(list []string) {
+	files := map[string]time.Time{r.file: modTime(r.file)}
+	for _, file := range list {
+		if file != "" {
+			files[file] = modTime(file)
+		}
+	}
+	r.mu.Lock()
+	r.files = files
+	r.mu.Unlock()
+}
+
+// watched returns the list of watched files.
+func (r *Reloader) watched() (list []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for file := range r.files {
+		list = append(list, file)
+	}
+	return list
+}
+
+// modTime returns the modification time of the file, or zero if missing.
+func modTime(file string) time.Time {
+	info, e := os.Stat(file)
+	if e != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
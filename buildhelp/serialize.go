@@ -0,0 +1,489 @@
+package buildhelp
+
+// #cgo pkg-config: gtk4
+// #include <stdlib.h>
+// #include <string.h>
+// #include <gtk/gtk.h>
+//
+// static GType object_type(GObject *obj) { return G_OBJECT_TYPE(obj); }
+// static const char *type_name(GType gtype) { return g_type_name(gtype); }
+// static const char *spec_name(GParamSpec *spec) { return spec->name; }
+//
+// static gboolean is_widget(GObject *obj) { return GTK_IS_WIDGET(obj); }
+//
+// static GParamSpec **object_properties(GObject *obj, guint *n) {
+// 	return g_object_class_list_properties(G_OBJECT_GET_CLASS(obj), n);
+// }
+//
+// // serialize_property returns the property value as written in interfaces,
+// // or NULL if unset or its type is not supported. Free with g_free.
+// static char *serialize_property(GObject *obj, GParamSpec *spec) {
+// 	GValue v = G_VALUE_INIT;
+// 	g_value_init(&v, spec->value_type);
+// 	g_object_get_property(obj, spec->name, &v);
+// 	char buf[G_ASCII_DTOSTR_BUF_SIZE];
+// 	char *str = NULL;
+// 	switch (G_TYPE_FUNDAMENTAL(spec->value_type)) {
+// 	case G_TYPE_BOOLEAN: str = g_strdup(g_value_get_boolean(&v) ? "True" : "False"); break;
+// 	case G_TYPE_CHAR:    str = g_strdup_printf("%d", g_value_get_schar(&v)); break;
+// 	case G_TYPE_UCHAR:   str = g_strdup_printf("%u", g_value_get_uchar(&v)); break;
+// 	case G_TYPE_INT:     str = g_strdup_printf("%d", g_value_get_int(&v)); break;
+// 	case G_TYPE_UINT:    str = g_strdup_printf("%u", g_value_get_uint(&v)); break;
+// 	case G_TYPE_LONG:    str = g_strdup_printf("%ld", g_value_get_long(&v)); break;
+// 	case G_TYPE_ULONG:   str = g_strdup_printf("%lu", g_value_get_ulong(&v)); break;
+// 	case G_TYPE_INT64:   str = g_strdup_printf("%" G_GINT64_FORMAT, g_value_get_int64(&v)); break;
+// 	case G_TYPE_UINT64:  str = g_strdup_printf("%" G_GUINT64_FORMAT, g_value_get_uint64(&v)); break;
+// 	case G_TYPE_FLOAT:   str = g_strdup(g_ascii_formatd(buf, sizeof buf, "%.7g", g_value_get_float(&v))); break;
+// 	case G_TYPE_DOUBLE:  str = g_strdup(g_ascii_dtostr(buf, sizeof buf, g_value_get_double(&v))); break;
+// 	case G_TYPE_STRING:  str = g_value_dup_string(&v); break;
+// 	case G_TYPE_ENUM: {
+// 		GEnumClass *klass = g_type_class_ref(spec->value_type);
+// 		GEnumValue *value = g_enum_get_value(klass, g_value_get_enum(&v));
+// 		str = value ? g_strdup(value->value_nick) : g_strdup_printf("%d", g_value_get_enum(&v));
+// 		g_type_class_unref(klass);
+// 		break;
+// 	}
+// 	case G_TYPE_FLAGS: {
+// 		GFlagsClass *klass = g_type_class_ref(spec->value_type);
+// 		guint flags = g_value_get_flags(&v);
+// 		GString *s = g_string_new(NULL);
+// 		for (guint i = 0; i < klass->n_values; i++) {
+// 			guint value = klass->values[i].value;
+// 			if (value != 0 && (flags & value) == value) {
+// 				g_string_append_printf(s, "%s%s", s->len > 0 ? "|" : "", klass->values[i].value_nick);
+// 				flags &= ~value;
+// 			}
+// 		}
+// 		if (flags != 0 || s->len == 0) {
+// 			g_string_append_printf(s, "%s%u", s->len > 0 ? "|" : "", flags);
+// 		}
+// 		str = g_string_free(s, FALSE);
+// 		g_type_class_unref(klass);
+// 		break;
+// 	}
+// 	}
+// 	g_value_unset(&v);
+// 	return str;
+// }
+//
+// static gboolean is_default(GParamSpec *spec, GObject *obj) {
+// 	GValue v = G_VALUE_INIT;
+// 	g_value_init(&v, spec->value_type);
+// 	g_object_get_property(obj, spec->name, &v);
+// 	gboolean is = g_param_value_defaults(spec, &v);
+// 	g_value_unset(&v);
+// 	return is;
+// }
+//
+// // object_child returns the object held by the property, or NULL.
+// static GObject *object_child(GObject *obj, const char *property) {
+// 	// Getters of properties added in later gtk versions.
+// 	if (GTK_IS_CENTER_BOX(obj)) {
+// 		GtkCenterBox *box = GTK_CENTER_BOX(obj);
+// 		if (strcmp(property, "start-widget") == 0) return (GObject *)gtk_center_box_get_start_widget(box);
+// 		if (strcmp(property, "center-widget") == 0) return (GObject *)gtk_center_box_get_center_widget(box);
+// 		if (strcmp(property, "end-widget") == 0) return (GObject *)gtk_center_box_get_end_widget(box);
+// 	}
+// 	if (GTK_IS_WINDOW(obj) && strcmp(property, "titlebar") == 0) {
+// 		return (GObject *)gtk_window_get_titlebar(GTK_WINDOW(obj));
+// 	}
+//
+// 	GObject *child = NULL;
+// 	if (!g_object_class_find_property(G_OBJECT_GET_CLASS(obj), property)) {
+// 		return NULL;
+// 	}
+// 	g_object_get(obj, property, &child, NULL);
+// 	if (child) {
+// 		g_object_unref(child); // Kept by its parent.
+// 	}
+// 	return child;
+// }
+//
+// static GObject *first_child(GObject *obj) { return (GObject *)gtk_widget_get_first_child(GTK_WIDGET(obj)); }
+// static GObject *next_sibling(GObject *obj) { return (GObject *)gtk_widget_get_next_sibling(GTK_WIDGET(obj)); }
+//
+// // css_classes returns the CSS classes of the widget separated by spaces.
+// static char *css_classes(GObject *obj) {
+// 	char **classes = gtk_widget_get_css_classes(GTK_WIDGET(obj));
+// 	char *str = g_strjoinv(" ", classes);
+// 	g_strfreev(classes);
+// 	return str;
+// }
+//
+// static GObject *stack_page(GObject *stack, GObject *child) {
+// 	return (GObject *)gtk_stack_get_page(GTK_STACK(stack), GTK_WIDGET(child));
+// }
+//
+// static int notebook_pages(GObject *nb) { return gtk_notebook_get_n_pages(GTK_NOTEBOOK(nb)); }
+// static GObject *notebook_page(GObject *nb, int i) { return (GObject *)gtk_notebook_get_nth_page(GTK_NOTEBOOK(nb), i); }
+// static GObject *notebook_tab(GObject *nb, GObject *page) {
+// 	return (GObject *)gtk_notebook_get_tab_label(GTK_NOTEBOOK(nb), GTK_WIDGET(page));
+// }
+//
+// // notebook_default_tab returns true if the tab is the "Page N" label given by
+// // the notebook to the page i without tab.
+// static gboolean notebook_default_tab(GObject *tab, int i) {
+// 	if (!GTK_IS_LABEL(tab)) {
+// 		return FALSE;
+// 	}
+// 	char *str = g_strdup_printf(g_dgettext("gtk40", "Page %u"), i + 1);
+// 	gboolean is = strcmp(gtk_label_get_label(GTK_LABEL(tab)), str) == 0;
+// 	g_free(str);
+// 	return is;
+// }
+//
+// // bar_box returns the box of the widgets packed at the start or end of a
+// // GtkHeaderBar or a GtkActionBar, or NULL.
+// static GObject *bar_box(GObject *bar, gboolean end) {
+// 	GtkWidget *w = gtk_widget_get_first_child(GTK_WIDGET(bar));
+// 	if (GTK_IS_WINDOW_HANDLE(w)) {
+// 		w = gtk_window_handle_get_child(GTK_WINDOW_HANDLE(w));
+// 	} else if (GTK_IS_REVEALER(w)) {
+// 		w = gtk_revealer_get_child(GTK_REVEALER(w));
+// 	}
+// 	if (!GTK_IS_CENTER_BOX(w)) {
+// 		return NULL;
+// 	}
+// 	w = end ? gtk_center_box_get_end_widget(GTK_CENTER_BOX(w)) : gtk_center_box_get_start_widget(GTK_CENTER_BOX(w));
+// 	return GTK_IS_BOX(w) ? (GObject *)w : NULL;
+// }
+//
+// static GObject *action_bar_center(GObject *bar) {
+// 	return (GObject *)gtk_action_bar_get_center_widget(GTK_ACTION_BAR(bar));
+// }
+//
+// static gboolean is_window_controls(GObject *obj) { return GTK_IS_WINDOW_CONTROLS(obj); }
+// static GObject *last_child(GObject *obj) { return (GObject *)gtk_widget_get_last_child(GTK_WIDGET(obj)); }
+// static GObject *prev_sibling(GObject *obj) { return (GObject *)gtk_widget_get_prev_sibling(GTK_WIDGET(obj)); }
+//
+// static void grid_layout(GObject *grid, GObject *child, int *col, int *row, int *width, int *height) {
+// 	gtk_grid_query_child(GTK_GRID(grid), GTK_WIDGET(child), col, row, width, height);
+// }
+//
+// static GObject *new_reference(GType gtype) {
+// 	if (G_TYPE_IS_ABSTRACT(gtype)) {
+// 		return NULL;
+// 	}
+// 	return g_object_ref_sink(g_object_new(gtype, NULL));
+// }
+//
+// static void free_reference(GObject *obj) {
+// 	if (GTK_IS_WINDOW(obj)) {
+// 		gtk_window_destroy(GTK_WINDOW(obj));
+// 	}
+// 	g_object_unref(obj);
+// }
+import "C"
+
+import (
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/gtkool4/gtkelp/uixml"
+
+	externglib "github.com/diamondburned/gotk4/pkg/core/glib"
+)
+
+// ChildProperty defines an object property holding a child widget, written as
+// a <child> element of the type.
+type ChildProperty struct {
+	Property string
+	Type     string // Child type: start, end, label... Empty for the main child.
+	Unless   string // Property that creates the child, so it's skipped when set: label for label-widget.
+}
+
+// SerializeChildren lists the child properties of widget classes, used by
+// Serialize. Subclasses inherit the children of their parent.
+//
+// Children of Box, Grid, ListBox, FlowBox, Stack, Notebook, Overlay overlays,
+// and the widgets packed in HeaderBar and ActionBar are always written.
+var SerializeChildren = map[string][]ChildProperty{
+	"GtkWindow":         {{Property: "titlebar", Type: "titlebar"}, {Property: "child"}},
+	"GtkPaned":          {{Property: "start-child", Type: "start"}, {Property: "end-child", Type: "end"}},
+	"GtkCenterBox":      {{Property: "start-widget", Type: "start"}, {Property: "center-widget", Type: "center"}, {Property: "end-widget", Type: "end"}},
+	"GtkFrame":          {{Property: "label-widget", Type: "label", Unless: "label"}, {Property: "child"}},
+	"GtkExpander":       {{Property: "label-widget", Type: "label", Unless: "label"}, {Property: "child"}},
+	"GtkAspectFrame":    {{Property: "child"}},
+	"GtkScrolledWindow": {{Property: "child"}},
+	"GtkViewport":       {{Property: "child"}},
+	"GtkRevealer":       {{Property: "child"}},
+	"GtkOverlay":        {{Property: "child"}},
+	"GtkPopover":        {{Property: "child"}},
+	"GtkListBoxRow":     {{Property: "child"}},
+	"GtkFlowBoxChild":   {{Property: "child"}},
+	"GtkWindowHandle":   {{Property: "child"}},
+	"GtkSearchBar":      {{Property: "child"}},
+	"GtkHeaderBar":      {{Property: "title-widget", Type: "title"}},
+}
+
+// SerializeSkip lists the properties never written by Serialize, for all
+// classes. The widget name is written as id.
+var SerializeSkip = map[string]bool{
+	"css-name":           true,
+	"css-classes":        true, // Written as <style>.
+	"visible-child-name": true, // Set before children are added.
+	"page":               true, // Notebook current page, same.
+}
+
+// Serialize returns the gtk.Builder interface of the widget tree, to save it as
+// a .ui file or load it again with NewFromString:
+//
+//   box := gtknew.VBox(6, gtk.NewLabel("Hello"), gtknew.HPaned(left, right))
+//   str := buildhelp.Serialize(box).String()
+//
+// Properties are written when they differ from a new object of the same class,
+// widget names are written as ids. Children are written for the classes of
+// SerializeChildren and the usual containers, children of other classes are not
+// written. Signals and objects held by properties, like models or adjustments,
+// are not written.
+//
+// The widget is an externglib.Objector, so windows can be given.
+func Serialize(widget externglib.Objector) *uixml.Node {
+	s := serializer{refs: map[C.GType]*C.GObject{}, ids: map[string]bool{}}
+	defer s.free()
+	obj := externglib.InternObject(widget)
+	root := uixml.New("interface").Append(
+		uixml.New("requires", "lib", "gtk", "version", "4.0"),
+		s.object((*C.GObject)(unsafe.Pointer(obj.Native()))),
+	)
+	return root
+}
+
+type serializer struct {
+	refs map[C.GType]*C.GObject // New objects by class, to compare properties.
+	ids  map[string]bool
+}
+
+// object returns the <object> element of the object and its children.
+func (s *serializer) object(obj *C.GObject) *uixml.Node {
+	gtype := C.object_type(obj)
+	n := uixml.New("object", "class", C.GoString(C.type_name(gtype)))
+	ref := s.reference(gtype)
+	isWidget := C.is_widget(obj) != 0
+
+	var count C.guint
+	specs := C.object_properties(obj, &count)
+	defer C.g_free(C.gpointer(unsafe.Pointer(specs)))
+	set := map[string]bool{}
+	for _, spec := range unsafe.Slice(specs, int(count)) {
+		name := C.GoString(C.spec_name(spec))
+		if !serializable(spec) {
+			continue
+		}
+		value, ok := property(obj, spec)
+		switch {
+		case !ok:
+			continue
+
+		case isWidget && name == "name":
+			if !s.ids[value] {
+				s.ids[value] = true
+				n.SetAttr("id", value)
+				continue
+			} // Duplicate names are kept as property.
+
+		case SerializeSkip[name]:
+			continue
+
+		case ref != nil:
+			if refValue, ok := property(ref, spec); ok && refValue == value {
+				continue
+			}
+
+		case C.is_default(spec, obj) != 0:
+			continue
+		}
+		prop := uixml.New("property", "name", name)
+		prop.Text = value
+		n.Append(prop)
+		set[name] = true
+	}
+
+	if isWidget {
+		s.style(n, obj, ref)
+		s.children(n, obj, gtype, set)
+	}
+	return n
+}
+
+// property returns the value of the property, or false if unset or not
+// supported.
+func property(obj *C.GObject, spec *C.GParamSpec) (string, bool) {
+	cstr := C.serialize_property(obj, spec)
+	if cstr == nil {
+		return "", false
+	}
+	defer C.g_free(C.gpointer(unsafe.Pointer(cstr)))
+	return C.GoString(cstr), true
+}
+
+// serializable returns true if the property can be read and set in interfaces.
+func serializable(spec *C.GParamSpec) bool {
+	flags := spec.flags
+	switch {
+	case flags&C.G_PARAM_READABLE == 0,
+		flags&(C.G_PARAM_WRITABLE|C.G_PARAM_CONSTRUCT_ONLY) == 0,
+		flags&C.G_PARAM_DEPRECATED != 0:
+		return false
+	}
+	return true
+}
+
+// style adds the CSS classes of the widget missing on a new widget.
+func (s *serializer) style(n *uixml.Node, obj, ref *C.GObject) {
+	skip := map[string]bool{"horizontal": true, "vertical": true} // Set by orientation.
+	if ref != nil {
+		for _, class := range cssClasses(ref) {
+			skip[class] = true
+		}
+	}
+	style := uixml.New("style")
+	for _, class := range cssClasses(obj) {
+		if !skip[class] {
+			style.Append(uixml.New("class", "name", class))
+		}
+	}
+	if len(style.Children) > 0 {
+		n.Append(style)
+	}
+}
+
+func cssClasses(obj *C.GObject) []string {
+	cstr := C.css_classes(obj)
+	defer C.g_free(C.gpointer(unsafe.Pointer(cstr)))
+	return strings.Fields(C.GoString(cstr))
+}
+
+// children adds the children of the widget, found by class.
+func (s *serializer) children(n *uixml.Node, obj *C.GObject, gtype C.GType, set map[string]bool) {
+	for t := gtype; t != 0; t = C.g_type_parent(t) {
+		class := C.GoString(C.type_name(t))
+		switch class {
+		case "GtkBox", "GtkListBox", "GtkFlowBox":
+			for child := C.first_child(obj); child != nil; child = C.next_sibling(child) {
+				n.Append(s.child("", s.object(child)))
+			}
+			return
+
+		case "GtkGrid":
+			for child := C.first_child(obj); child != nil; child = C.next_sibling(child) {
+				var col, row, width, height C.int
+				C.grid_layout(obj, child, &col, &row, &width, &height)
+				layout := uixml.New("layout")
+				for i, value := range []int{int(col), int(row), int(width), int(height)} {
+					if value != 1 || i < 2 { // Spans are 1 by default.
+						prop := uixml.New("property", "name", []string{"column", "row", "column-span", "row-span"}[i])
+						prop.Text = strconv.Itoa(value)
+						layout.Append(prop)
+					}
+				}
+				n.Append(s.child("", s.object(child).Append(layout)))
+			}
+			return
+
+		case "GtkStack":
+			for child := C.first_child(obj); child != nil; child = C.next_sibling(child) {
+				page := s.object(C.stack_page(obj, child))
+				prop := uixml.New("property", "name", "child").Append(s.object(child))
+				n.Append(s.child("", page.Append(prop)))
+			}
+			return
+
+		case "GtkNotebook":
+			for i := C.int(0); i < C.notebook_pages(obj); i++ {
+				page := C.notebook_page(obj, i)
+				n.Append(s.child("", s.object(page)))
+				if tab := C.notebook_tab(obj, page); tab != nil && C.notebook_default_tab(tab, i) == 0 {
+					n.Append(s.child("tab", s.object(tab)))
+				}
+			}
+			return
+
+		case "GtkActionBar":
+			s.packed(n, obj)
+			if center := C.action_bar_center(obj); center != nil {
+				n.Append(s.child("center", s.object(center)))
+			}
+			return
+
+		case "GtkHeaderBar":
+			s.packed(n, obj) // Then the title widget of SerializeChildren.
+		}
+
+		props, ok := SerializeChildren[class]
+		if !ok {
+			continue
+		}
+		var main *C.GObject
+		for _, p := range props {
+			if p.Unless != "" && set[p.Unless] {
+				continue
+			}
+			cname := C.CString(p.Property)
+			child := C.object_child(obj, cname)
+			C.free(unsafe.Pointer(cname))
+			if child != nil {
+				n.Append(s.child(p.Type, s.object(child)))
+				if p.Type == "" {
+					main = child
+				}
+			}
+		}
+		if class == "GtkOverlay" {
+			for child := C.first_child(obj); child != nil; child = C.next_sibling(child) {
+				if child != main {
+					n.Append(s.child("overlay", s.object(child)))
+				}
+			}
+		}
+		return
+	}
+}
+
+// packed adds the children packed at the start and end of a HeaderBar or an
+// ActionBar. End children are packed from the end, so they are written last
+// first.
+func (s *serializer) packed(n *uixml.Node, bar *C.GObject) {
+	if box := C.bar_box(bar, 0); box != nil {
+		for child := C.first_child(box); child != nil; child = C.next_sibling(child) {
+			if C.is_window_controls(child) == 0 {
+				n.Append(s.child("start", s.object(child)))
+			}
+		}
+	}
+	if box := C.bar_box(bar, 1); box != nil {
+		for child := C.last_child(box); child != nil; child = C.prev_sibling(child) {
+			if C.is_window_controls(child) == 0 {
+				n.Append(s.child("end", s.object(child)))
+			}
+		}
+	}
+}
+
+// child returns a <child> element of the type.
+func (s *serializer) child(typ string, obj *uixml.Node) *uixml.Node {
+	n := uixml.New("child")
+	if typ != "" {
+		n.SetAttr("type", typ)
+	}
+	return n.Append(obj)
+}
+
+// reference returns a new object of the class, or nil if abstract.
+func (s *serializer) reference(gtype C.GType) *C.GObject {
+	ref, ok := s.refs[gtype]
+	if !ok {
+		ref = C.new_reference(gtype)
+		s.refs[gtype] = ref
+	}
+	return ref
+}
+
+func (s *serializer) free() {
+	for _, ref := range s.refs {
+		if ref != nil {
+			C.free_reference(ref)
+		}
+	}
+}
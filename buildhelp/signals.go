@@ -0,0 +1,227 @@
+package buildhelp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/gtkool4/grun"
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+// Errors formating.
+var (
+	FmtErrHandlerNotFound = "builder found no handler %s for signal %s of %s"                       // Format: handler, signal, id
+	FmtErrHandlerNotFunc  = "builder handler %s for signal %s of %s is not a func but a %T"         // Format: handler, signal, id, value
+	FmtErrHandlerArgs     = "builder handler %s has %d arguments, signal %s of %s gives %d"         // Format: handler, args, signal, id, max
+	FmtErrHandlerUnused   = "builder handler %s is not used by any signal"                          // Format: handler
+	FmtErrSignalNotFound  = "builder found no signal %s for key %s (%s)"                            // Format: signal, id, class
+	FmtErrSignalAttr      = "builder signal %s of %s: attribute %s is not supported by go handlers" // Format: signal, id, attribute
+	TxtErrHandlersNil     = "builder handlers are nil"
+)
+
+// FmtSignalID formats the id given to objects without id that declare signals.
+var FmtSignalID = "_buildhelp_signal_%d"
+
+// IgnoredHandlers lists receiver methods never reported as unused handlers.
+var IgnoredHandlers = []string{"String", "Error"}
+
+// signal defines a <signal> element declared in the interface.
+type signal struct {
+	id, class   string // Object.
+	name        string // Detailed signal name: notify::label.
+	handler     string
+	after       bool
+	unsupported string // Attribute name.
+	pos         uixml.Pos
+}
+
+// extractSignals removes the <signal> elements from the interface, to
+// connect them later. Returns true if signals were found.
+func (b *BuildHelp) extractSignals(root *uixml.Node) bool {
+	found := false
+	root.Walk(func(n, _ *uixml.Node) bool {
+		if n.Name != "object" && n.Name != "template" {
+			return true
+		}
+		kept := n.Children[:0]
+		for _, child := range n.Children {
+			if child.Name != "signal" {
+				kept = append(kept, child)
+				continue
+			}
+			if n.Attr("id") == "" && n.Name == "object" {
+				n.SetAttr("id", fmt.Sprintf(FmtSignalID, len(b.signals)))
+			}
+			sig := signal{
+				id:      n.Attr("id"),
+				class:   n.Attr("class"),
+				name:    child.Attr("name"),
+				handler: child.Attr("handler"),
+				after:   isTrue(child.Attr("after")),
+				pos:     child.Pos,
+			}
+			for _, attr := range []string{"object", "swapped"} {
+				if child.HasAttr(attr) {
+					sig.unsupported = attr
+				}
+			}
+			b.signals = append(b.signals, sig)
+			found = true
+		}
+		n.Children = kept
+		return true
+	})
+	return found
+}
+
+//
+//---------------------------------------------------------[ CONNECT SIGNALS ]--
+
+// ConnectSignals connects the signals declared in the interface with
+// <signal name="clicked" handler="Cut"/> to the go handlers of the same name.
+//
+// Handlers can be the methods of a receiver, or a map[string]interface{} of
+// funcs. Like other callbacks, a handler can omit the last arguments of the
+// signal, but can't ask for more.
+//
+// Every unmatched handler, bad arity or unused handler is appended to the
+// builder errors, which are returned for convenience. Unused handlers are
+// the map entries, or the receiver methods not promoted from an embedded field
+// and not in IgnoredHandlers. Nil handlers are an error too.
+//
+// Keep a reference to the receiver or the map to prevent the GC from freeing
+// handlers. The object and swapped attributes are not supported.
+func (b *BuildHelp) ConnectSignals(handlers interface{}) grun.Errors {
+	if !reflect.ValueOf(handlers).IsValid() {
+		b.errors.Append(errors.New(TxtErrHandlersNil))
+		return b.errors
+	}
+	funcs, candidates := handlerFuncs(handlers)
+	used := map[string]bool{}
+	for _, sig := range b.signals {
+		used[sig.handler] = true
+		fn, ok := funcs(sig.handler)
+		switch {
+		case sig.unsupported != "":
+			b.errors.Append(fmt.Errorf(FmtErrSignalAttr, sig.name, sig.id, sig.unsupported))
+			continue
+
+		case !ok:
+			b.errors.Append(fmt.Errorf(FmtErrHandlerNotFound, sig.handler, sig.name, sig.id))
+			continue
+
+		case fn.Kind() != reflect.Func:
+			b.errors.Append(fmt.Errorf(FmtErrHandlerNotFunc, sig.handler, sig.name, sig.id, valueOf(fn)))
+			continue
+		}
+
+		b.fetch(sig.id, "signal")
+		obj := b.object(sig.id)
+		if obj == nil {
+			b.errors.Append(&NotFoundError{ID: sig.id, Type: sig.class})
+			continue
+		}
+
+		nParams := signalParams(obj, sig.name)
+		switch {
+		case nParams < 0:
+			b.errors.Append(fmt.Errorf(FmtErrSignalNotFound, sig.name, sig.id, sig.class))
+
+		case fn.Type().NumIn() > nParams+1: // The instance is the first argument.
+			b.errors.Append(fmt.Errorf(FmtErrHandlerArgs, sig.handler, fn.Type().NumIn(), sig.name, sig.id, nParams+1))
+
+		case sig.after:
+			obj.ConnectAfter(sig.name, fn.Interface())
+
+		default:
+			obj.Connect(sig.name, fn.Interface())
+		}
+	}
+
+	for _, name := range candidates {
+		if !used[name] {
+			b.errors.Append(fmt.Errorf(FmtErrHandlerUnused, name))
+		}
+	}
+	return b.errors
+}
+
+// handlerFuncs returns the handler lookup func and the list of candidates to
+// report if unused.
+func handlerFuncs(handlers interface{}) (func(string) (reflect.Value, bool), []string) {
+	if m, ok := handlers.(map[string]interface{}); ok {
+		var names []string
+		for name := range m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return func(name string) (reflect.Value, bool) {
+			fn, ok := m[name]
+			return reflect.ValueOf(fn), ok
+		}, names
+	}
+
+	v := reflect.ValueOf(handlers)
+	var names []string
+	for i := 0; i < v.NumMethod(); i++ {
+		name := v.Type().Method(i).Name
+		if !isPromoted(v.Type(), name) && !contains(IgnoredHandlers, name) {
+			names = append(names, name)
+		}
+	}
+	return func(name string) (reflect.Value, bool) {
+		fn := v.MethodByName(name)
+		return fn, fn.IsValid()
+	}, names
+}
+
+// isPromoted returns true if the method comes from an embedded field.
+func isPromoted(t reflect.Type, method string) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.Anonymous {
+			continue
+		}
+		if _, ok := reflect.PtrTo(field.Type).MethodByName(method); ok {
+			return true
+		}
+		if _, ok := field.Type.MethodByName(method); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// valueOf returns the value of the reflect.Value, or nil if invalid.
+func valueOf(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// isTrue parses a gtk.Builder boolean.
+func isTrue(str string) bool {
+	switch str {
+	case "1", "y", "yes", "t", "true", "TRUE", "True", "Yes", "YES":
+		return true
+	}
+	return false
+}
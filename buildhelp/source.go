@@ -0,0 +1,285 @@
+package buildhelp
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gtkool4/gtkelp/uixml"
+
+	"github.com/diamondburned/gotk4/pkg/gdk/v4"
+	"github.com/diamondburned/gotk4/pkg/gio/v2"
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+)
+
+// FileProperties lists properties whose value can be a file relative to the
+// interface file, like the image file of a GtkPicture. They are only resolved
+// when the class property holds a file, an icon or a paintable, or is the file
+// name of a GtkImage.
+var FileProperties = []string{"file", "gicon", "paintable", "texture"}
+
+// Errors formating.
+var (
+	FmtErrInclude      = "include %s: %v"    // Format: file, error
+	FmtErrIncludeCycle = "include cycle: %s" // Format: chain
+	TxtErrIncludeFile  = "element needs a file attribute"
+	TxtErrNoDisplay    = "no default display to load CSS"
+)
+
+// source defines where interface files and their references are loaded from.
+type source interface {
+	// ReadFile returns the content of the file.
+	ReadFile(name string) ([]byte, error)
+	// Join returns the path of the file relative to the directory of base.
+	Join(base, name string) string
+	// Ref returns the reference of the file usable by gtk.Builder, like an
+	// absolute path, or false if the file doesn't exist.
+	Ref(name string) (string, bool)
+}
+
+//
+//------------------------------------------------------------------[ SOURCE ]--
+
+// diskSource loads files from the disk.
+type diskSource struct{}
+
+func (diskSource) ReadFile(name string) ([]byte, error) { return os.ReadFile(name) }
+
+func (diskSource) Join(base, name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(filepath.Dir(base), name)
+}
+
+func (diskSource) Ref(name string) (string, bool) {
+	abs, e := filepath.Abs(name)
+	if e != nil {
+		return "", false
+	}
+	_, e = os.Stat(abs)
+	return abs, e == nil
+}
+
+// fsSource loads files from a fs.FS. Referenced files are extracted to a
+// temporary directory removed by Close.
+type fsSource struct {
+	fsys fs.FS
+	b    *BuildHelp
+}
+
+func (s fsSource) ReadFile(name string) ([]byte, error) { return fs.ReadFile(s.fsys, name) }
+
+func (fsSource) Join(base, name string) string { return path.Join(path.Dir(base), name) }
+
+func (s fsSource) Ref(name string) (string, bool) {
+	data, e := fs.ReadFile(s.fsys, name)
+	if e != nil {
+		return "", false
+	}
+	if s.b.tmpDir == "" {
+		s.b.tmpDir, e = os.MkdirTemp("", "buildhelp")
+		if e != nil {
+			return "", false
+		}
+	}
+	file := filepath.Join(s.b.tmpDir, filepath.FromSlash(name))
+	if os.MkdirAll(filepath.Dir(file), 0700) != nil || os.WriteFile(file, data, 0600) != nil {
+		return "", false
+	}
+	return file, true
+}
+
+// resourceSource loads files from registered GResources.
+type resourceSource struct{}
+
+func (resourceSource) ReadFile(name string) ([]byte, error) {
+	data, e := gio.ResourcesLookupData(name, gio.ResourceLookupFlagsNone)
+	if e != nil {
+		return nil, e
+	}
+	return data.Data(), nil
+}
+
+func (resourceSource) Join(base, name string) string { return path.Join(path.Dir(base), name) }
+
+func (resourceSource) Ref(name string) (string, bool) {
+	_, _, e := gio.ResourcesGetInfo(name, gio.ResourceLookupFlagsNone)
+	return "resource://" + name, e == nil
+}
+
+//
+//------------------------------------------------------------[ LOAD FROM FS ]--
+
+// NewFromFS creates a *BuildHelp to load gtk.Builder interfaces easily from
+// files in a fs.FS, like an embed.FS. All files are loaded in the same builder.
+//
+// Panics if an interface is invalid, see ParseFS to get the error.
+func NewFromFS(fsys fs.FS, paths ...string) *BuildHelp { return must(ParseFS(fsys, paths...)) }
+
+// NewFromResource creates a *BuildHelp to load gtk.Builder interfaces easily
+// from registered GResources. All files are loaded in the same builder.
+//
+// Panics if an interface is invalid, see ParseResource to get the error.
+func NewFromResource(paths ...string) *BuildHelp { return must(ParseResource(paths...)) }
+
+// ParseFS creates a *BuildHelp from interface files in a fs.FS, or returns an
+// error if an interface is invalid.
+//
+// Interfaces can use those elements, with paths relative to the interface file:
+//
+//	<include file="header.ui"/>      Inserts the content of another interface.
+//	<css file="style.css"/>          Loads a CSS file for the default display.
+//
+// Properties listed in FileProperties can also reference a file relative to
+// the interface, like <property name="file">icons/logo.png</property>. Those
+// files are extracted to a temporary directory, removed with Close.
+func ParseFS(fsys fs.FS, paths ...string) (*BuildHelp, error) {
+	b := New()
+	return b.loadFiles(fsSource{fsys: fsys, b: b}, paths)
+}
+
+// ParseResource creates a *BuildHelp from interface files in registered
+// GResources, or returns an error if an interface is invalid.
+//
+// Includes, CSS and file references are resolved like ParseFS, with resource
+// paths.
+func ParseResource(paths ...string) (*BuildHelp, error) {
+	return New().loadFiles(resourceSource{}, paths)
+}
+
+func (b *BuildHelp) loadFiles(src source, paths []string) (*BuildHelp, error) {
+	for _, file := range paths {
+		data, e := src.ReadFile(file)
+		if e == nil {
+			e = b.load(src, data, file, uixml.Parsers[path.Ext(file)])
+		}
+		if e != nil {
+			b.Close()
+			return nil, e
+		}
+	}
+	return b, nil
+}
+
+// Close removes files extracted from a fs.FS and unloads CSS providers.
+func (b *BuildHelp) Close() {
+	if display := gdk.DisplayGetDefault(); display != nil {
+		for _, provider := range b.css {
+			gtk.StyleContextRemoveProviderForDisplay(display, provider)
+		}
+	}
+	b.css = nil
+	if b.tmpDir != "" {
+		os.RemoveAll(b.tmpDir)
+		b.tmpDir = ""
+	}
+}
+
+//
+//-----------------------------------------------------------------[ EXPAND ]--
+
+// expand resolves includes, CSS and file references of the interface.
+// Returns true if the document was changed.
+func (b *BuildHelp) expand(src source, root *uixml.Node, file string, stack []string) (changed bool, e error) {
+	b.files = append(b.files, file)
+	root.Walk(func(n, parent *uixml.Node) bool {
+		if e != nil {
+			return false
+		}
+		if n.Name == "property" && contains(FileProperties, n.Attr("name")) && isRelative(n.Text) &&
+			parent != nil && b.isFileProperty(parent.Attr("class"), n.Attr("name")) {
+			if ref, ok := src.Ref(src.Join(file, n.Text)); ok {
+				n.Text, changed = ref, true
+			}
+		}
+
+		children := make([]*uixml.Node, 0, len(n.Children))
+		for _, child := range n.Children {
+			switch child.Name {
+			case "include":
+				var nodes []*uixml.Node
+				nodes, e = b.include(src, child, file, stack, n.Name != "interface")
+				children = append(children, nodes...)
+				changed = true
+
+			case "css":
+				e = b.loadCSS(src, child, file)
+				changed = true
+
+			default:
+				children = append(children, child)
+			}
+			if e != nil {
+				return false
+			}
+		}
+		n.Children = children
+		return true
+	})
+	return changed, e
+}
+
+// include returns the expanded content of the included interface.
+func (b *BuildHelp) include(src source, n *uixml.Node, file string, stack []string, dropRequires bool) ([]*uixml.Node, error) {
+	if n.Attr("file") == "" {
+		return nil, &ParseError{Pos: n.Pos, Msg: TxtErrIncludeFile}
+	}
+
+	name := src.Join(file, n.Attr("file"))
+	stack = append(stack, file)
+	if contains(stack, name) {
+		cycle := strings.Join(append(stack, name), " > ")
+		return nil, &ParseError{Pos: n.Pos, Msg: fmt.Sprintf(FmtErrIncludeCycle, cycle)}
+	}
+
+	data, e := src.ReadFile(name)
+	if e != nil {
+		return nil, &ParseError{Pos: n.Pos, Msg: fmt.Sprintf(FmtErrInclude, name, e), Err: e}
+	}
+	root, e := uixml.ParseFormat(data, name)
+	if e == nil {
+		_, e = b.expand(src, root, name, stack)
+	}
+	if e != nil {
+		return nil, parseError(e, name, nil)
+	}
+
+	var nodes []*uixml.Node
+	for _, child := range root.Children {
+		if !dropRequires || child.Name != "requires" {
+			nodes = append(nodes, child)
+		}
+	}
+	return nodes, nil
+}
+
+// loadCSS loads the CSS file for the default display.
+func (b *BuildHelp) loadCSS(src source, n *uixml.Node, file string) error {
+	if n.Attr("file") == "" {
+		return &ParseError{Pos: n.Pos, Msg: TxtErrIncludeFile}
+	}
+	display := gdk.DisplayGetDefault()
+	if display == nil {
+		return &ParseError{Pos: n.Pos, Msg: TxtErrNoDisplay}
+	}
+	name := src.Join(file, n.Attr("file"))
+	data, e := src.ReadFile(name)
+	if e != nil {
+		return &ParseError{Pos: n.Pos, Msg: fmt.Sprintf(FmtErrInclude, name, e), Err: e}
+	}
+
+	provider := gtk.NewCSSProvider()
+	provider.LoadFromData(data)
+	gtk.StyleContextAddProviderForDisplay(display, provider, gtk.STYLE_PROVIDER_PRIORITY_APPLICATION)
+	b.css = append(b.css, provider)
+	return nil
+}
+
+// isRelative returns true if the property value looks like a relative path.
+func isRelative(str string) bool {
+	return str != "" && !strings.Contains(str, "://") && !strings.HasPrefix(str, "/")
+}
@@ -0,0 +1,113 @@
+package buildhelp
+
+import (
+	"github.com/gtkool4/gtkelp/uixml"
+
+	externglib "github.com/diamondburned/gotk4/pkg/core/glib"
+)
+
+// Template is an interface parsed and validated once, to create many
+// independent instances, like list rows or repeated panels.
+type Template struct {
+	proto   *BuildHelp // Validation instance, keeps loaded CSS.
+	root    *uixml.Node
+	data    string
+	lines   []uixml.Pos
+	signals []signal
+	objects Audit
+}
+
+// NewTemplate parses and validates the interface, or returns a *ParseError if
+// the interface is invalid.
+//
+// Includes and file references are relative to the current directory, like
+// ParseString.
+func NewTemplate(str string) (*Template, error) {
+	root, e := uixml.ParseString(str, "")
+	if e != nil {
+		return nil, parseError(e, "", nil)
+	}
+	t := &Template{proto: New(), root: root}
+	if _, e := t.proto.expand(diskSource{}, root, "", nil); e != nil {
+		t.proto.Close()
+		return nil, parseError(e, "", nil)
+	}
+	t.proto.auditObjects(root)
+	t.proto.extractSignals(root)
+	translate(root)
+	t.signals, t.objects = t.proto.signals, t.proto.objects
+
+	data, lines := root.Render()
+	t.data, t.lines = string(data), lines
+	if e := t.proto.AddFromString(t.data, -1); e != nil {
+		t.proto.Close()
+		return nil, parseError(e, "", t.lines)
+	}
+	return t, nil
+}
+
+// IDs returns the sorted list of ids defined in the template.
+func (t *Template) IDs() []string { return t.root.IDs() }
+
+// New creates an instance in its own builder, with the same ids.
+//
+// The instance has its own accessors and errors, and can connect the template
+// signals with ConnectSignals.
+//
+// gtk.Builder can't copy objects, so each instance still parses the rendered
+// XML with AddFromString. The template only saves the Go side work: parsing,
+// includes, signals, translations and validation. NewIn also renders the ids
+// with the prefix each time.
+func (t *Template) New() (*BuildHelp, error) {
+	b := New()
+	b.signals, b.objects = t.signals, t.objects
+	if e := b.AddFromString(t.data, -1); e != nil {
+		return nil, parseError(e, "", t.lines)
+	}
+	return b, nil
+}
+
+// NewIn creates an instance in the builder dst, with ids prefixed so several
+// instances can coexist: the "ok" object is "row1.ok" in dst with prefix
+// "row1.". References to objects of the template are also prefixed.
+//
+// The returned *BuildHelp shares the dst builder, but its accessors, signals
+// and errors use the ids without prefix: b.Button("ok").
+func (t *Template) NewIn(dst *BuildHelp, prefix string) (*BuildHelp, error) {
+	root := t.root.Copy()
+	prefixIDs(root, prefix)
+	data, lines := root.Render()
+	if e := dst.AddFromString(string(data), -1); e != nil {
+		return nil, parseError(e, "", lines)
+	}
+
+	b := &BuildHelp{Builder: dst.Builder, signals: t.signals, objects: t.objects}
+	b.lookup = func(id string) *externglib.Object { return dst.GetObject(prefix + id) }
+	return b, nil
+}
+
+// Close unloads the CSS loaded by the template.
+func (t *Template) Close() { t.proto.Close() }
+
+// prefixIDs adds the prefix to the ids of the document, and to references to
+// those ids.
+func prefixIDs(root *uixml.Node, prefix string) {
+	ids := root.IDs()
+	root.Walk(func(n, parent *uixml.Node) bool {
+		if n.Name != "property" && contains(ids, n.Attr("id")) {
+			n.SetAttr("id", prefix+n.Attr("id"))
+		}
+
+		switch {
+		case n.Name == "widget" && contains(ids, n.Attr("name")): // Size group member.
+			n.SetAttr("name", prefix+n.Attr("name"))
+
+		case !contains(ids, n.Text):
+
+		case n.Name == "property" && parent != nil && isObjectProperty(parent.Attr("class"), n.Attr("name")),
+			n.Name == "action-widget", n.Name == "lookup":
+			n.Text = prefix + n.Text
+		}
+		return true
+	})
+}
@@ -0,0 +1,76 @@
+package buildhelp
+
+import (
+	"io/fs"
+
+	"github.com/gtkool4/gtkelp/gettext"
+	"github.com/gtkool4/gtkelp/gtkext"
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+// Translations is the catalog used to translate interfaces when they are
+// loaded, and Go strings with Gettext and friends. When nil, gtk.Builder
+// translates interfaces with the system catalogs.
+var Translations *gettext.Catalog
+
+// LoadTranslations loads the catalog of the domain from the fs.FS and sets it
+// as Translations and gtkext.Translate. Without languages, the user languages
+// are used. See gettext.Load for the catalog paths:
+//
+//   //go:embed locale
+//   var locale embed.FS
+//
+//   sub, _ := fs.Sub(locale, "locale")
+//   buildhelp.LoadTranslations(sub, "myapp") // fr/LC_MESSAGES/myapp.mo...
+//
+// The error wraps gettext.ErrNotFound when no catalog matches: strings are
+// then untranslated.
+func LoadTranslations(fsys fs.FS, domain string, langs ...string) error {
+	cat, e := gettext.Load(fsys, domain, langs...)
+	if e != nil {
+		return e
+	}
+	Translations = cat
+	gtkext.Translate = Gettext
+	return nil
+}
+
+// Gettext returns the translation of the message with Translations.
+func Gettext(msgid string) string { return Translations.Gettext(msgid) }
+
+// PGettext returns the translation of the message in the context with
+// Translations.
+func PGettext(context, msgid string) string { return Translations.PGettext(context, msgid) }
+
+// NGettext returns the plural form of the translation for the count n with
+// Translations.
+func NGettext(msgid, plural string, n int) string { return Translations.NGettext(msgid, plural, n) }
+
+// NPGettext returns the plural form of the translation in the context for the
+// count n with Translations.
+func NPGettext(context, msgid, plural string, n int) string {
+	return Translations.NPGettext(context, msgid, plural, n)
+}
+
+// translate replaces translatable strings of the interface with Translations,
+// so gtk.Builder doesn't translate them again. Returns true if the document was
+// changed.
+func translate(root *uixml.Node) (changed bool) {
+	if Translations == nil {
+		return false
+	}
+	root.Walk(func(n, _ *uixml.Node) bool {
+		if !n.HasAttr("translatable") {
+			return true
+		}
+		if gettext.IsTranslatable(n) && n.Text != "" {
+			n.Text = Translations.PGettext(n.Attr("context"), n.Text)
+		}
+		for _, attr := range []string{"translatable", "context", "comments"} {
+			n.DelAttr(attr)
+		}
+		changed = true
+		return true
+	})
+	return changed
+}
@@ -0,0 +1,207 @@
+package main
+
+// kind defines how a property value is written in Go.
+type kind int
+
+const (
+	kindString kind = iota
+	kindBool
+	kindInt   // Integer literal, for int and uint arguments.
+	kindFloat // Number literal, for float32 and float64 arguments.
+	kindEnum  // gtk constant, see Setter.Enum.
+)
+
+// Setter defines the method setting a property.
+type Setter struct {
+	Method string
+	Kind   kind
+	Enum   string   // Prefix of gtk enum constants for kindEnum: Align gives gtk.AlignCenter.
+	Args   []string // Properties given together, like width-request and height-request.
+	Zero   string   // Value of missing Args.
+}
+
+// Class defines how a widget class is converted.
+type Class struct {
+	Parent   string            // Setters are inherited, up to GtkWidget.
+	New      string            // Constructor call, without the package: NewButton(). Empty for gtknew helpers.
+	Label    string            // String property given to the constructor: NewLabel(%s).
+	Setters  map[string]Setter // By property name.
+	Children string            // How children are added, see addChildren.
+	AsChild  string            // Format of the expression given to parents, if it isn't a gtk.Widgetter.
+}
+
+// Classes lists the classes converted, by GType name.
+var Classes = map[string]*Class{
+	"GtkWidget": {Setters: map[string]Setter{
+		"name":           {Method: "SetName"},
+		"tooltip-text":   {Method: "SetTooltipText"},
+		"tooltip-markup": {Method: "SetTooltipMarkup"},
+		"hexpand":        {Method: "SetHExpand", Kind: kindBool},
+		"vexpand":        {Method: "SetVExpand", Kind: kindBool},
+		"halign":         {Method: "SetHAlign", Kind: kindEnum, Enum: "Align"},
+		"valign":         {Method: "SetVAlign", Kind: kindEnum, Enum: "Align"},
+		"margin-start":   {Method: "SetMarginStart", Kind: kindInt},
+		"margin-end":     {Method: "SetMarginEnd", Kind: kindInt},
+		"margin-top":     {Method: "SetMarginTop", Kind: kindInt},
+		"margin-bottom":  {Method: "SetMarginBottom", Kind: kindInt},
+		"sensitive":      {Method: "SetSensitive", Kind: kindBool},
+		"visible":        {Method: "SetVisible", Kind: kindBool},
+		"can-focus":      {Method: "SetCanFocus", Kind: kindBool},
+		"focusable":      {Method: "SetFocusable", Kind: kindBool},
+		"opacity":        {Method: "SetOpacity", Kind: kindFloat},
+		"width-request":  {Method: "SetSizeRequest", Kind: kindInt, Args: []string{"width-request", "height-request"}, Zero: "-1"},
+		"height-request": {Method: "SetSizeRequest", Kind: kindInt, Args: []string{"width-request", "height-request"}, Zero: "-1"},
+	}},
+
+	"GtkWindow": {New: "NewWindow()", Children: "window", Setters: map[string]Setter{
+		"title":          {Method: "SetTitle"},
+		"modal":          {Method: "SetModal", Kind: kindBool},
+		"resizable":      {Method: "SetResizable", Kind: kindBool},
+		"decorated":      {Method: "SetDecorated", Kind: kindBool},
+		"deletable":      {Method: "SetDeletable", Kind: kindBool},
+		"hide-on-close":  {Method: "SetHideOnClose", Kind: kindBool},
+		"icon-name":      {Method: "SetIconName"},
+		"default-width":  {Method: "SetDefaultSize", Kind: kindInt, Args: []string{"default-width", "default-height"}, Zero: "-1"},
+		"default-height": {Method: "SetDefaultSize", Kind: kindInt, Args: []string{"default-width", "default-height"}, Zero: "-1"},
+	}},
+	"GtkApplicationWindow": {Parent: "GtkWindow", New: "NewApplicationWindow(app)", Children: "window"},
+	"GtkDialog":            {Parent: "GtkWindow", New: "NewDialog()", Children: "window"},
+
+	"GtkBox":       {Children: "box", Setters: map[string]Setter{"homogeneous": {Method: "SetHomogeneous", Kind: kindBool}}},
+	"GtkCenterBox": {Children: "centerbox"},
+	"GtkPaned":     {Children: "paned", Setters: map[string]Setter{"position": {Method: "SetPosition", Kind: kindInt}}},
+	"GtkScrolledWindow": {Children: "scrolled", Setters: map[string]Setter{
+		"has-frame":                {Method: "SetHasFrame", Kind: kindBool},
+		"min-content-width":        {Method: "SetMinContentWidth", Kind: kindInt},
+		"min-content-height":       {Method: "SetMinContentHeight", Kind: kindInt},
+		"propagate-natural-width":  {Method: "SetPropagateNaturalWidth", Kind: kindBool},
+		"propagate-natural-height": {Method: "SetPropagateNaturalHeight", Kind: kindBool},
+		"hscrollbar-policy":        {Method: "SetPolicy", Kind: kindEnum, Enum: "Policy", Args: []string{"hscrollbar-policy", "vscrollbar-policy"}, Zero: "gtk.PolicyAutomatic"},
+		"vscrollbar-policy":        {Method: "SetPolicy", Kind: kindEnum, Enum: "Policy", Args: []string{"hscrollbar-policy", "vscrollbar-policy"}, Zero: "gtk.PolicyAutomatic"},
+	}},
+	"GtkFrame": {New: "NewFrame(%s)", Label: "label", Children: "frame", Setters: map[string]Setter{
+		"label-xalign": {Method: "SetLabelAlign", Kind: kindFloat},
+	}},
+	"GtkExpander": {New: "NewExpander(%s)", Label: "label", Children: "frame", Setters: map[string]Setter{
+		"expanded":      {Method: "SetExpanded", Kind: kindBool},
+		"use-markup":    {Method: "SetUseMarkup", Kind: kindBool},
+		"use-underline": {Method: "SetUseUnderline", Kind: kindBool},
+	}},
+	"GtkGrid": {New: "NewGrid()", Children: "grid", Setters: map[string]Setter{
+		"row-spacing":        {Method: "SetRowSpacing", Kind: kindInt},
+		"column-spacing":     {Method: "SetColumnSpacing", Kind: kindInt},
+		"row-homogeneous":    {Method: "SetRowHomogeneous", Kind: kindBool},
+		"column-homogeneous": {Method: "SetColumnHomogeneous", Kind: kindBool},
+	}},
+	"GtkStack": {New: "NewStack()", Children: "stack", Setters: map[string]Setter{
+		"transition-type":     {Method: "SetTransitionType", Kind: kindEnum, Enum: "StackTransitionType"},
+		"transition-duration": {Method: "SetTransitionDuration", Kind: kindInt},
+	}},
+	"GtkStackSwitcher": {New: "NewStackSwitcher()"},
+	"GtkNotebook":      {New: "NewNotebook()", Children: "notebook"},
+	"GtkOverlay":       {New: "NewOverlay()", Children: "overlay"},
+	"GtkRevealer": {New: "NewRevealer()", Children: "child", Setters: map[string]Setter{
+		"reveal-child":        {Method: "SetRevealChild", Kind: kindBool},
+		"transition-type":     {Method: "SetTransitionType", Kind: kindEnum, Enum: "RevealerTransitionType"},
+		"transition-duration": {Method: "SetTransitionDuration", Kind: kindInt},
+	}},
+	"GtkHeaderBar": {New: "NewHeaderBar()", Children: "pack", Setters: map[string]Setter{
+		"show-title-buttons": {Method: "SetShowTitleButtons", Kind: kindBool},
+		"decoration-layout":  {Method: "SetDecorationLayout"},
+	}},
+	"GtkActionBar":  {New: "NewActionBar()", Children: "pack"},
+	"GtkListBox":    {New: "NewListBox()", Children: "insert"},
+	"GtkListBoxRow": {New: "NewListBoxRow()", Children: "child"},
+	"GtkFlowBox":    {New: "NewFlowBox()", Children: "insert"},
+
+	"GtkLabel": {New: "NewLabel(%s)", Label: "label", Setters: map[string]Setter{
+		"use-markup":       {Method: "SetUseMarkup", Kind: kindBool},
+		"use-underline":    {Method: "SetUseUnderline", Kind: kindBool},
+		"wrap":             {Method: "SetWrap", Kind: kindBool},
+		"selectable":       {Method: "SetSelectable", Kind: kindBool},
+		"single-line-mode": {Method: "SetSingleLineMode", Kind: kindBool},
+		"justify":          {Method: "SetJustify", Kind: kindEnum, Enum: "Justify"},
+		"xalign":           {Method: "SetXAlign", Kind: kindFloat},
+		"yalign":           {Method: "SetYAlign", Kind: kindFloat},
+		"lines":            {Method: "SetLines", Kind: kindInt},
+		"width-chars":      {Method: "SetWidthChars", Kind: kindInt},
+		"max-width-chars":  {Method: "SetMaxWidthChars", Kind: kindInt},
+	}},
+	"GtkButton": {New: "NewButton()", Children: "child", Setters: map[string]Setter{
+		"label":         {Method: "SetLabel"},
+		"icon-name":     {Method: "SetIconName"},
+		"has-frame":     {Method: "SetHasFrame", Kind: kindBool},
+		"use-underline": {Method: "SetUseUnderline", Kind: kindBool},
+	}},
+	"GtkToggleButton": {Parent: "GtkButton", New: "NewToggleButton()", Children: "child", Setters: map[string]Setter{
+		"active": {Method: "SetActive", Kind: kindBool},
+	}},
+	"GtkCheckButton": {New: "NewCheckButton()", Setters: map[string]Setter{
+		"label":         {Method: "SetLabel"},
+		"active":        {Method: "SetActive", Kind: kindBool},
+		"use-underline": {Method: "SetUseUnderline", Kind: kindBool},
+	}},
+	"GtkLinkButton": {Parent: "GtkButton", New: "NewLinkButton(%s)", Label: "uri", Children: "child"},
+	"GtkMenuButton": {New: "NewMenuButton()", AsChild: "&%s.Widget", Setters: map[string]Setter{
+		"label":     {Method: "SetLabel"},
+		"icon-name": {Method: "SetIconName"},
+	}},
+	"GtkSwitch":  {New: "NewSwitch()", Setters: map[string]Setter{"active": {Method: "SetActive", Kind: kindBool}}},
+	"GtkSpinner": {New: "NewSpinner()", Setters: map[string]Setter{"spinning": {Method: "SetSpinning", Kind: kindBool}}},
+	"GtkEntry": {New: "NewEntry()", Setters: map[string]Setter{
+		"text":              {Method: "SetText"},
+		"placeholder-text":  {Method: "SetPlaceholderText"},
+		"visibility":        {Method: "SetVisibility", Kind: kindBool},
+		"max-length":        {Method: "SetMaxLength", Kind: kindInt},
+		"has-frame":         {Method: "SetHasFrame", Kind: kindBool},
+		"activates-default": {Method: "SetActivatesDefault", Kind: kindBool},
+	}},
+	"GtkSearchEntry":   {New: "NewSearchEntry()"},
+	"GtkPasswordEntry": {New: "NewPasswordEntry()"},
+	"GtkImage": {New: "NewImage()", Setters: map[string]Setter{
+		"icon-name":  {Method: "SetFromIconName"},
+		"file":       {Method: "SetFromFile"},
+		"resource":   {Method: "SetFromResource"},
+		"pixel-size": {Method: "SetPixelSize", Kind: kindInt},
+	}},
+	"GtkPicture": {New: "NewPicture()", Setters: map[string]Setter{
+		"filename":          {Method: "SetFilename"},
+		"can-shrink":        {Method: "SetCanShrink", Kind: kindBool},
+		"keep-aspect-ratio": {Method: "SetKeepAspectRatio", Kind: kindBool},
+	}},
+	"GtkProgressBar": {New: "NewProgressBar()", Setters: map[string]Setter{
+		"fraction":  {Method: "SetFraction", Kind: kindFloat},
+		"text":      {Method: "SetText"},
+		"show-text": {Method: "SetShowText", Kind: kindBool},
+	}},
+	"GtkLevelBar": {New: "NewLevelBar()", Setters: map[string]Setter{
+		"value":     {Method: "SetValue", Kind: kindFloat},
+		"min-value": {Method: "SetMinValue", Kind: kindFloat},
+		"max-value": {Method: "SetMaxValue", Kind: kindFloat},
+	}},
+	"GtkSeparator": {Children: "separator"},
+	"GtkTextView": {New: "NewTextView()", Setters: map[string]Setter{
+		"editable":       {Method: "SetEditable", Kind: kindBool},
+		"monospace":      {Method: "SetMonospace", Kind: kindBool},
+		"cursor-visible": {Method: "SetCursorVisible", Kind: kindBool},
+		"wrap-mode":      {Method: "SetWrapMode", Kind: kindEnum, Enum: "Wrap"},
+	}},
+}
+
+// setter returns the setter of the property for the class or its parents.
+func setter(class, property string) (Setter, bool) {
+	for class != "" {
+		c := Classes[class]
+		if c == nil {
+			break
+		}
+		if s, ok := c.Setters[property]; ok {
+			return s, true
+		}
+		class = c.Parent
+		if class == "" && c != Classes["GtkWidget"] {
+			class = "GtkWidget"
+		}
+	}
+	return Setter{}, false
+}
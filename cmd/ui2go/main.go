@@ -0,0 +1,643 @@
+// Command ui2go converts a gtk.Builder file into Go code building the same
+// widget tree, with gtknew helpers and gotk4 constructors and setters.
+//
+// Objects with an id are returned as fields of a struct, like uigen. Classes,
+// properties and elements that can't be converted, like signals, are left as
+// TODO comments in the code.
+//
+// Usage:
+//
+//   ui2go [-type MainUI] [-package main] [-o main_ui.go] main.ui
+//
+// The conversion is a starting point meant to be edited: the output has no
+// "DO NOT EDIT" header.
+//
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gtkool4/gtkelp/gettext"
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+// Import paths of packages used by the generated code.
+var imports = map[string]string{
+	"buildhelp": "github.com/gtkool4/gtkelp/buildhelp",
+	"gtkext":    "github.com/gtkool4/gtkelp/gtkext",
+	"gtknew":    "github.com/gtkool4/gtkelp/gtknew",
+	"gtk":       "github.com/diamondburned/gotk4/pkg/gtk/v4",
+}
+
+// ChildProperties gives the child type of object properties holding a child
+// widget, like <property name="start-child">.
+var ChildProperties = map[string]string{
+	"child":         "",
+	"start-child":   "start",
+	"end-child":     "end",
+	"start-widget":  "start",
+	"center-widget": "center",
+	"end-widget":    "end",
+	"titlebar":      "titlebar",
+	"label-widget":  "label",
+	"title-widget":  "title",
+}
+
+// TODO comments formating.
+var (
+	FmtTodoClass    = "class %s is not converted"               // Format: class
+	FmtTodoProperty = "property %s of %s is not converted"      // Format: property, class
+	FmtTodoValue    = "property %s value %q is not converted"   // Format: property, value
+	FmtTodoBind     = "property %s binding is not converted"    // Format: property
+	FmtTodoSignal   = "connect signal %s to handler %s"         // Format: signal, handler
+	FmtTodoChild    = "child of type %q in %s is not converted" // Format: type, class
+	FmtTodoElement  = "element <%s> is not converted"           // Format: element
+)
+
+func main() {
+	typeName := flag.String("type", "", "struct type name (default: file name + UI)")
+	pkgName := flag.String("package", os.Getenv("GOPACKAGE"), "package name (default: $GOPACKAGE or main)")
+	output := flag.String("o", "", "output file (default: file_ui.go)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: ui2go [flags] file.ui")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file := flag.Arg(0)
+	base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	if *typeName == "" {
+		*typeName = uixml.GoName(base) + "UI"
+	}
+	if *pkgName == "" {
+		*pkgName = "main"
+	}
+	if *output == "" {
+		*output = filepath.Join(filepath.Dir(file), base+"_ui.go")
+	}
+
+	root, e := uixml.ParseFile(file)
+	if e != nil {
+		fail(e)
+	}
+	src, e := Generate(root, filepath.Base(file), *pkgName, *typeName)
+	if e != nil {
+		fail(e)
+	}
+	if e := os.WriteFile(*output, src, 0644); e != nil {
+		fail(e)
+	}
+}
+
+func fail(e error) {
+	fmt.Fprintln(os.Stderr, "ui2go:", e)
+	os.Exit(1)
+}
+
+//
+//----------------------------------------------------------------[ GENERATE ]--
+
+// field defines a struct field to generate.
+type field struct {
+	name, typ, class string
+}
+
+// child defines a converted child widget.
+type child struct {
+	typ  string // Child type: start, end, titlebar...
+	expr string
+	node *uixml.Node // The <child> element, for its layout.
+}
+
+type generator struct {
+	body     []string
+	fields   []field
+	names    map[string]bool // Field names.
+	vars     map[string]int  // Variable counters by base name.
+	used     map[string]bool // Imported packages.
+	needsApp bool
+}
+
+// Generate returns the formatted Go source building the interface.
+func Generate(root *uixml.Node, file, pkgName, typeName string) ([]byte, error) {
+	g := &generator{names: map[string]bool{}, vars: map[string]int{"ui": 1}, used: map[string]bool{"gtk": true}}
+	for _, n := range root.Children {
+		switch n.Name {
+		case "object":
+			g.object(n, true)
+		case "requires":
+		default:
+			g.todo(n, FmtTodoElement, n.Name)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code converted by ui2go from %s.\n\npackage %s\n\nimport (\n", file, pkgName)
+	var paths []string
+	for pkg := range g.used {
+		paths = append(paths, imports[pkg])
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(&buf, "%q\n", path)
+	}
+	fmt.Fprintf(&buf, ")\n\n// %s holds the widgets of %s.\ntype %s struct {\n", typeName, file, typeName)
+	for _, f := range g.fields {
+		fmt.Fprintf(&buf, "%s %s // %s\n", f.name, f.typ, f.class)
+	}
+	params := ""
+	if g.needsApp {
+		params = "app *gtk.Application"
+	}
+	fmt.Fprintf(&buf, "}\n\n// New%[1]s builds the widgets of %[2]s.\nfunc New%[1]s(%[3]s) *%[1]s {\nui := &%[1]s{}\n", typeName, file, params)
+	for _, line := range g.body {
+		buf.WriteString(line + "\n")
+	}
+	buf.WriteString("return ui\n}\n")
+	return format.Source(buf.Bytes())
+}
+
+// object converts the object and its children, and returns its expression, or
+// an empty string if it can't be converted. Top level objects and objects with
+// an id are struct fields, other objects are variables when they need
+// statements, or inlined.
+func (g *generator) object(n *uixml.Node, top bool) string {
+	class := n.Attr("class")
+	c := Classes[class]
+	if c == nil {
+		g.todo(n, FmtTodoClass, class)
+		return ""
+	}
+	if strings.Contains(c.New, "app") {
+		g.needsApp = true
+	}
+
+	var (
+		props    []*uixml.Node
+		children []child
+		stmts    []string // Statements on the object, %[1]s is its name.
+	)
+	todo := func(n *uixml.Node, format string, args ...interface{}) {
+		stmts = append(stmts, todoLine(n, format, args...))
+	}
+	for _, elem := range n.Children {
+		switch elem.Name {
+		case "property":
+			if typ, ok := ChildProperties[elem.Attr("name")]; ok && len(elem.Elements("object")) > 0 {
+				g.child(typ, elem, elem.Elements("object")[0], &children)
+			} else {
+				props = append(props, elem)
+			}
+
+		case "child":
+			if elem.HasAttr("internal-child") {
+				todo(elem, FmtTodoElement, "child internal-child")
+				continue
+			}
+			for _, obj := range elem.Elements("object") {
+				if c.Children == "stack" && obj.Attr("class") == "GtkStackPage" {
+					g.stackPage(elem, obj, &children, todo)
+				} else {
+					g.child(elem.Attr("type"), elem, obj, &children)
+				}
+			}
+
+		case "layout": // Read by the parent, see gridLayout.
+
+		case "signal":
+			todo(elem, FmtTodoSignal, elem.Attr("name"), elem.Attr("handler"))
+
+		case "style":
+			for _, class := range elem.Elements("class") {
+				stmts = append(stmts, fmt.Sprintf("%%[1]s.AddCSSClass(%q)", class.Attr("name")))
+			}
+
+		default:
+			todo(elem, FmtTodoElement, elem.Name)
+		}
+	}
+
+	// Properties given to the constructor.
+	args := map[string]*uixml.Node{}
+	var names []string
+	switch c.Children {
+	case "box":
+		names = []string{"orientation", "spacing"}
+	case "paned", "separator":
+		names = []string{"orientation"}
+	}
+	if c.Label != "" {
+		names = append(names, c.Label)
+	}
+	var rest []*uixml.Node
+	for _, p := range props {
+		if contains(names, p.Attr("name")) && !p.HasAttr("bind-source") {
+			args[p.Attr("name")] = p
+		} else {
+			rest = append(rest, p)
+		}
+	}
+	stmts = append(stmts, g.setters(class, rest)...)
+
+	id := n.Attr("id")
+	expr, children := g.construct(c, class, args, children, id == "" && !top && len(stmts) == 0)
+	stmts = append(g.addChildren(class, c, children), stmts...)
+
+	var name string
+	switch {
+	case id != "" || top:
+		pkg, typ := uixml.GoType(class)
+		fname := typ
+		if id != "" {
+			fname = uixml.GoName(id)
+		}
+		fname = uixml.UniqueName(g.names, fname)
+		g.fields = append(g.fields, field{name: fname, typ: "*" + pkg + "." + typ, class: class})
+		name = "ui." + fname
+		g.body = append(g.body, name+" = "+expr)
+
+	case len(stmts) > 0:
+		_, typ := uixml.GoType(class)
+		base := strings.ToLower(typ[:1]) + typ[1:]
+		g.vars[base]++
+		name = fmt.Sprintf("%s%d", base, g.vars[base])
+		g.body = append(g.body, name+" := "+expr)
+
+	default:
+		return expr
+	}
+	for _, stmt := range stmts {
+		if strings.HasPrefix(stmt, "//") {
+			g.body = append(g.body, stmt)
+		} else {
+			g.body = append(g.body, fmt.Sprintf(stmt, name))
+		}
+	}
+	return name
+}
+
+// child converts the child object and appends it to the list.
+func (g *generator) child(typ string, elem, obj *uixml.Node, list *[]child) {
+	if expr := g.widget(obj); expr != "" {
+		*list = append(*list, child{typ: typ, expr: expr, node: elem})
+	}
+}
+
+// widget converts the child object and returns its expression as a
+// gtk.Widgetter, or an empty string.
+func (g *generator) widget(obj *uixml.Node) string {
+	expr := g.object(obj, false)
+	if c := Classes[obj.Attr("class")]; expr != "" && c.AsChild != "" {
+		expr = fmt.Sprintf(c.AsChild, expr)
+	}
+	return expr
+}
+
+// construct returns the constructor expression of the object, and the
+// children not given to it. gtknew helpers returning a gtk.Widgetter are only
+// used inline.
+func (g *generator) construct(c *Class, class string, args map[string]*uixml.Node, children []child, inline bool) (string, []child) {
+	hv := "H"
+	if p := args["orientation"]; p != nil && strings.Contains(strings.ToLower(p.Text), "vertical") {
+		hv = "V"
+	}
+	// take removes and returns the first child of the type, or the first
+	// untyped child as fallback.
+	take := func(typ string, fallback bool) string {
+		for _, try := range []string{typ, ""} {
+			for i, ch := range children {
+				if ch.typ == try {
+					children = append(children[:i:i], children[i+1:]...)
+					return ch.expr
+				}
+			}
+			if !fallback {
+				break
+			}
+		}
+		return "nil"
+	}
+
+	switch c.Children {
+	case "box":
+		g.used["gtknew"] = true
+		list := []string{"0"}
+		if p := args["spacing"]; p != nil {
+			if _, e := strconv.Atoi(strings.TrimSpace(p.Text)); e == nil {
+				list[0] = strings.TrimSpace(p.Text)
+			}
+		}
+		for expr := take("", false); expr != "nil"; expr = take("", false) {
+			list = append(list, expr)
+		}
+		return "gtknew." + hv + "Box(" + strings.Join(list, ", ") + ")", children
+
+	case "centerbox":
+		g.used["gtknew"] = true
+		start, center, end := take("start", false), take("center", false), take("end", false)
+		return fmt.Sprintf("gtknew.CenterBox(%s, %s, %s)", start, center, end), children
+
+	case "paned":
+		g.used["gtknew"] = true
+		start := take("start", true)
+		return fmt.Sprintf("gtknew.%sPaned(%s, %s)", hv, start, take("end", true)), children
+
+	case "scrolled":
+		g.used["gtknew"] = true
+		return "gtknew.ScrolledWindow(" + take("", false) + ")", children
+
+	case "separator":
+		g.used["gtknew"] = true
+		return "gtknew." + hv + "Sep()", children
+
+	case "frame":
+		label := args[c.Label]
+		simple := len(children) == 0 || len(children) == 1 && children[0].typ == ""
+		if inline && simple {
+			g.used["gtknew"] = true
+			list := []string{g.label(label)}
+			if len(children) == 1 {
+				list = append(list, children[0].expr)
+			}
+			return "gtknew." + strings.TrimPrefix(class, "Gtk") + "(" + strings.Join(list, ", ") + ")", nil
+		}
+	}
+
+	if c.Label != "" {
+		return "gtk." + fmt.Sprintf(c.New, g.label(args[c.Label])), children
+	}
+	return "gtk." + c.New, children
+}
+
+// addChildren returns the statements adding the children to the object.
+func (g *generator) addChildren(class string, c *Class, children []child) (stmts []string) {
+	hasChild := false
+	lastPage := ""
+	for _, ch := range children {
+		method := ""
+		switch c.Children + "/" + ch.typ {
+		case "window/", "child/", "frame/", "overlay/":
+			if !hasChild {
+				method, hasChild = "SetChild(%s)", true
+			}
+		case "window/titlebar":
+			method = "SetTitlebar(%s)"
+		case "frame/label":
+			method = "SetLabelWidget(%s)"
+		case "overlay/overlay":
+			method = "AddOverlay(%s)"
+		case "pack/", "pack/start":
+			method = "PackStart(%s)"
+		case "pack/end":
+			method = "PackEnd(%s)"
+		case "pack/title":
+			if class == "GtkHeaderBar" {
+				method = "SetTitleWidget(%s)"
+			}
+		case "insert/":
+			method = "Insert(%s, -1)"
+		case "notebook/":
+			method, lastPage = "AppendPage(%s, nil)", ch.expr
+		case "notebook/tab":
+			if lastPage != "" {
+				method = "SetTabLabel(" + lastPage + ", %s)"
+			}
+		case "stack/":
+			method = "AddChild(%s)"
+		case "grid/":
+			method = "Attach(%s, " + strings.Join(gridLayout(ch.node), ", ") + ")"
+		default:
+			if strings.HasPrefix(ch.typ, "stack:") { // From stackPage.
+				method = strings.TrimPrefix(ch.typ, "stack:")
+			}
+		}
+		if method == "" {
+			stmts = append(stmts, todoLine(ch.node, FmtTodoChild, ch.typ, class))
+			continue
+		}
+		stmts = append(stmts, "%[1]s."+fmt.Sprintf(method, ch.expr))
+	}
+	return stmts
+}
+
+// setters returns the statements setting the properties.
+func (g *generator) setters(class string, props []*uixml.Node) (stmts []string) {
+	done := map[string]bool{}
+	for _, p := range props {
+		name := p.Attr("name")
+		s, ok := setter(class, name)
+		switch {
+		case done[name]:
+			continue
+		case p.HasAttr("bind-source"):
+			stmts = append(stmts, todoLine(p, FmtTodoBind, name))
+			continue
+		case !ok:
+			stmts = append(stmts, todoLine(p, FmtTodoProperty, name, class))
+			continue
+		}
+
+		list := []*uixml.Node{p}
+		if len(s.Args) > 0 { // Properties set together, from all the list.
+			list = nil
+			for _, arg := range s.Args {
+				var found *uixml.Node
+				for _, p := range props {
+					if p.Attr("name") == arg && !p.HasAttr("bind-source") {
+						found = p
+					}
+				}
+				list = append(list, found)
+				done[arg] = true
+			}
+		}
+		var values []string
+		for _, p := range list {
+			if p == nil {
+				values = append(values, s.Zero)
+				continue
+			}
+			value, ok := g.value(s, p)
+			if !ok {
+				stmts = append(stmts, todoLine(p, FmtTodoValue, p.Attr("name"), p.Text))
+				values = nil
+				break
+			}
+			values = append(values, value)
+		}
+		if values != nil {
+			stmts = append(stmts, "%[1]s."+s.Method+"("+strings.Join(values, ", ")+")")
+		}
+	}
+	return stmts
+}
+
+// stackPage converts a GtkStackPage child: AddTitled or AddNamed.
+func (g *generator) stackPage(elem, page *uixml.Node, children *[]child, todo func(*uixml.Node, string, ...interface{})) {
+	var obj *uixml.Node
+	args := map[string]string{}
+	for _, p := range page.Elements("property") {
+		switch name := p.Attr("name"); {
+		case name == "child" && len(p.Elements("object")) > 0:
+			obj = p.Elements("object")[0]
+		case name == "name" || name == "title":
+			args[name] = g.label(p)
+		default:
+			todo(p, FmtTodoProperty, name, "GtkStackPage")
+		}
+	}
+	if obj == nil {
+		return
+	}
+	expr := g.widget(obj)
+	if expr == "" {
+		return
+	}
+	method := "AddChild(%s)"
+	switch {
+	case args["title"] != "":
+		method = "AddTitled(%s, " + orEmpty(args["name"]) + ", " + args["title"] + ")"
+	case args["name"] != "":
+		method = "AddNamed(%s, " + args["name"] + ")"
+	}
+	*children = append(*children, child{typ: "stack:" + method, expr: expr, node: elem})
+}
+
+// gridLayout returns the column, row, width and height of a grid child, from
+// the <layout> of its object.
+func gridLayout(n *uixml.Node) []string {
+	values := map[string]string{"column": "0", "row": "0", "column-span": "1", "row-span": "1"}
+	var layouts []*uixml.Node
+	for _, obj := range n.Elements("object") {
+		layouts = append(layouts, obj.Elements("layout")...)
+	}
+	for _, layout := range layouts {
+		for _, p := range layout.Elements("property") {
+			if _, ok := values[p.Attr("name")]; ok {
+				if _, e := strconv.Atoi(strings.TrimSpace(p.Text)); e == nil {
+					values[p.Attr("name")] = strings.TrimSpace(p.Text)
+				}
+			}
+		}
+	}
+	return []string{values["column"], values["row"], values["column-span"], values["row-span"]}
+}
+
+//
+//------------------------------------------------------------------[ VALUES ]--
+
+// value returns the Go expression of the property value for the setter.
+func (g *generator) value(s Setter, p *uixml.Node) (string, bool) {
+	text := strings.TrimSpace(p.Text)
+	switch s.Kind {
+	case kindString:
+		return g.label(p), true
+
+	case kindBool:
+		switch strings.ToLower(text) {
+		case "true", "yes", "1", "t", "y":
+			return "true", true
+		case "false", "no", "0", "f", "n":
+			return "false", true
+		}
+
+	case kindInt:
+		_, e := strconv.ParseInt(text, 10, 64)
+		return text, e == nil
+
+	case kindFloat:
+		_, e := strconv.ParseFloat(text, 64)
+		return text, e == nil
+
+	case kindEnum:
+		return enumConst(s.Enum, text)
+	}
+	return "", false
+}
+
+// label returns the string expression of the property, translated if needed.
+func (g *generator) label(p *uixml.Node) string {
+	switch {
+	case p == nil:
+		return `""`
+
+	case p.Attr("context") != "" && gettext.IsTranslatable(p):
+		g.used["buildhelp"] = true
+		return fmt.Sprintf("buildhelp.PGettext(%q, %q)", p.Attr("context"), p.Text)
+
+	case gettext.IsTranslatable(p):
+		g.used["gtkext"] = true
+		return fmt.Sprintf("gtkext.Tr(%q)", p.Text)
+	}
+	return strconv.Quote(p.Text)
+}
+
+// enumConst returns the gtk constant of an enum value given as nick or C
+// identifier: center or GTK_ALIGN_CENTER is gtk.AlignCenter for prefix Align.
+func enumConst(prefix, value string) (string, bool) {
+	value = strings.ToUpper(strings.ReplaceAll(value, "-", "_"))
+	value = strings.TrimPrefix(value, "GTK_"+upperSnake(prefix)+"_")
+	if value == "" || value[0] < 'A' || value[0] > 'Z' || strings.Trim(value, "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_") != "" {
+		return "", false // Numeric values and unknown formats.
+	}
+	out := "gtk." + prefix
+	for _, word := range strings.Split(value, "_") {
+		if word != "" {
+			out += word[:1] + strings.ToLower(word[1:])
+		}
+	}
+	return out, true
+}
+
+// upperSnake converts a Go name to C style: StackTransitionType is
+// STACK_TRANSITION_TYPE.
+func upperSnake(name string) string {
+	var out []rune
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			out = append(out, '_')
+		}
+		out = append(out, unicode.ToUpper(r))
+	}
+	return string(out)
+}
+
+//
+//-----------------------------------------------------------------[ HELPERS ]--
+
+func (g *generator) todo(n *uixml.Node, format string, args ...interface{}) {
+	g.body = append(g.body, todoLine(n, format, args...))
+}
+
+func todoLine(n *uixml.Node, format string, args ...interface{}) string {
+	return "// TODO: " + fmt.Sprintf(format, args...) + " (line " + strconv.Itoa(n.Pos.Line) + ")."
+}
+
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+func orEmpty(expr string) string {
+	if expr == "" {
+		return `""`
+	}
+	return expr
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, file := range []string{"main", "misc"} {
+		root, e := uixml.ParseFile("testdata/" + file + ".ui")
+		if e != nil {
+			t.Fatal("parse error:", e)
+		}
+		have, e := Generate(root, file+".ui", "sample", uixml.GoName(file)+"UI")
+		if e != nil {
+			t.Fatal("generate error:", e)
+		}
+		expected, _ := os.ReadFile("testdata/" + file + "_ui.go.golden")
+		if string(have) != string(expected) {
+			t.Errorf("generated %s.ui does not match the golden file:\n%s", file, have)
+		}
+	}
+}
+
+func TestEnumConst(t *testing.T) {
+	for _, test := range []struct{ prefix, value, expected string }{
+		{"Align", "center", "gtk.AlignCenter"},
+		{"Align", "GTK_ALIGN_CENTER", "gtk.AlignCenter"},
+		{"StackTransitionType", "slide-left-right", "gtk.StackTransitionTypeSlideLeftRight"},
+		{"StackTransitionType", "GTK_STACK_TRANSITION_TYPE_OVER_UP", "gtk.StackTransitionTypeOverUp"},
+		{"Justify", "3", ""},
+		{"Justify", "bad value", ""},
+	} {
+		if have, _ := enumConst(test.prefix, test.value); have != test.expected {
+			t.Errorf("enum %s should be %s but we have %s", test.value, test.expected, have)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Command uiconv converts gtk.Builder interfaces between the XML, JSON, YAML
+// and uidsl formats. The JSON and YAML formats are described in the uixml
+// package and load with buildhelp.NewFromJSON and NewFromYAML, the uidsl syntax
+// is described in the uidsl package.
+//
+// The input format is given by the file extension: .ui, .json, .yaml, .yml or
+// .uidsl. XML is converted to YAML by default, and other formats to XML.
+//
+// Usage:
+//
+//   uiconv [-to ui|json|yaml|uidsl] [-o main.yaml] main.ui
+//
+// Without -o, the result is written to the standard output. Elements that the
+// formats can't describe, like menus, are kept as XML strings.
+//
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gtkool4/gtkelp/uidsl"
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+// FmtErrFormat formats the error of an unknown output format.
+var FmtErrFormat = "unknown format %s, use ui, json, yaml or uidsl" // Format: format
+
+func main() {
+	to := flag.String("to", "", "output format: ui, json, yaml or uidsl (default: yaml for .ui files, else ui)")
+	output := flag.String("o", "", "output file (default: standard output)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: uiconv [flags] file")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file := flag.Arg(0)
+	if *to == "" {
+		*to = "ui"
+		if _, ok := uixml.Parsers[filepath.Ext(file)]; !ok {
+			*to = "yaml"
+		}
+	}
+	data, e := os.ReadFile(file)
+	if e != nil {
+		fail(e)
+	}
+	data, e = Convert(data, file, *to)
+	if e != nil {
+		fail(e)
+	}
+	if *output == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if e := os.WriteFile(*output, data, 0644); e != nil {
+		fail(e)
+	}
+}
+
+func fail(e error) {
+	fmt.Fprintln(os.Stderr, "uiconv:", e)
+	os.Exit(1)
+}
+
+// Convert converts the interface data to the format: ui, json, yaml or uidsl.
+// The input format is given by the file extension.
+func Convert(data []byte, file, to string) ([]byte, error) {
+	root, e := uixml.ParseFormat(data, file)
+	if e != nil {
+		return nil, e
+	}
+	switch to {
+	case "ui", "xml":
+		return []byte(root.String()), nil
+
+	case "json":
+		return root.JSON(), nil
+
+	case "yaml", "yml":
+		return root.YAML(), nil
+
+	case "uidsl":
+		return uidsl.Write(root), nil
+	}
+	return nil, fmt.Errorf(FmtErrFormat, to)
+}
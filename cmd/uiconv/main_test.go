@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	src, _ := os.ReadFile("testdata/main.ui")
+	for _, to := range []string{"yaml", "json", "uidsl"} {
+		have, e := Convert(src, "main.ui", to)
+		if e != nil {
+			t.Fatal("convert error:", e)
+		}
+		expected, _ := os.ReadFile("testdata/main." + to + ".golden")
+		if string(have) != string(expected) {
+			t.Errorf("converted main.ui does not match the %s golden file:\n%s", to, have)
+		}
+
+		back, e := Convert(have, "main."+to, "ui")
+		if e != nil {
+			t.Fatalf("convert %s error: %v", to, e)
+		}
+		if string(back) != string(src) {
+			t.Errorf("%s converted back should match main.ui:\n%s", to, back)
+		}
+	}
+
+	if _, e := Convert(src, "main.ui", "toml"); e == nil {
+		t.Error("convert to an unknown format should fail")
+	}
+}
@@ -0,0 +1,101 @@
+// Command uidsl compiles interfaces written in the uidsl syntax to gtk.Builder
+// files, or formats them.
+//
+// Each file.uidsl is compiled to file.ui next to it, for use with go:generate:
+//
+//   //go:generate go run github.com/gtkool4/gtkelp/cmd/uidsl main.uidsl
+//
+// Usage:
+//
+//   uidsl [-o main.ui] file.uidsl...    Compile.
+//   uidsl -fmt [-w] file.uidsl...       Format, to the standard output or files.
+//
+// Use uiconv -to uidsl to convert a gtk.Builder file to the syntax.
+//
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gtkool4/gtkelp/uidsl"
+)
+
+func main() {
+	output := flag.String("o", "", "output file, with a single input (default: file.ui)")
+	format := flag.Bool("fmt", false, "format the files instead")
+	write := flag.Bool("w", false, "with -fmt, write the result to the files")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: uidsl [flags] file.uidsl...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 || *output != "" && (flag.NArg() > 1 || *format) {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	failed := false
+	for _, file := range flag.Args() {
+		var e error
+		if *format {
+			e = formatFile(file, *write)
+		} else {
+			e = compileFile(file, *output)
+		}
+		if e != nil {
+			fmt.Fprintln(os.Stderr, "uidsl:", e)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// compileFile compiles the file to the output, or file.ui.
+func compileFile(file, output string) error {
+	data, e := Compile(file)
+	if e != nil {
+		return e
+	}
+	if output == "" {
+		output = strings.TrimSuffix(file, uidsl.Ext) + ".ui"
+	}
+	return os.WriteFile(output, data, 0644)
+}
+
+// Compile returns the gtk.Builder document of the file.
+func Compile(file string) ([]byte, error) {
+	src, e := os.ReadFile(file)
+	if e != nil {
+		return nil, e
+	}
+	root, e := uidsl.Parse(src, file)
+	if e != nil {
+		return nil, e
+	}
+	return root.Bytes(), nil
+}
+
+// formatFile prints the formatted file, or rewrites it if changed.
+func formatFile(file string, write bool) error {
+	src, e := os.ReadFile(file)
+	if e != nil {
+		return e
+	}
+	data, e := uidsl.Format(src, file)
+	switch {
+	case e != nil:
+		return e
+	case !write:
+		_, e = os.Stdout.Write(data)
+		return e
+	case bytes.Equal(src, data):
+		return nil
+	}
+	return os.WriteFile(file, data, 0644)
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	have, e := Compile("testdata/main.uidsl")
+	if e != nil {
+		t.Fatal("compile error:", e)
+	}
+	expected, _ := os.ReadFile("testdata/main.ui.golden")
+	if string(have) != string(expected) {
+		t.Errorf("compiled main.uidsl does not match the golden file:\n%s", have)
+	}
+
+	_, e = Compile("testdata/bad.uidsl")
+	if e == nil || !strings.HasPrefix(e.Error(), "testdata/bad.uidsl:3:26: ") {
+		t.Error("compile error should have the position, not", e)
+	}
+}
@@ -0,0 +1,151 @@
+// Command uigen generates typed Go accessor structs from gtk.Builder files.
+//
+// Each object with an id becomes a field of the struct, typed with its gotk4
+// type, so renamed ids and changed classes become compile errors.
+//
+// Usage with go generate:
+//
+//   //go:generate go run github.com/gtkool4/gtkelp/cmd/uigen -type MainUI main.ui
+//
+// The generated struct is filled with buildhelp.BuildHelp.Bind:
+//
+//   ui, errs := NewMainUI(buildhelp.NewFromString(uiMain))
+//
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+// Import paths of gotk4 packages used by the generated code.
+var imports = map[string]string{
+	"buildhelp":  "github.com/gtkool4/gtkelp/buildhelp",
+	"externglib": "github.com/diamondburned/gotk4/pkg/core/glib",
+	"gdk":        "github.com/diamondburned/gotk4/pkg/gdk/v4",
+	"gdkpixbuf":  "github.com/diamondburned/gotk4/pkg/gdkpixbuf/v2",
+	"gio":        "github.com/diamondburned/gotk4/pkg/gio/v2",
+	"grun":       "github.com/gtkool4/grun",
+	"gtk":        "github.com/diamondburned/gotk4/pkg/gtk/v4",
+}
+
+func main() {
+	typeName := flag.String("type", "", "struct type name (default: file name + UI)")
+	pkgName := flag.String("package", os.Getenv("GOPACKAGE"), "package name (default: $GOPACKAGE or main)")
+	output := flag.String("o", "", "output file (default: file_ui.go)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: uigen [flags] file.ui")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file := flag.Arg(0)
+	base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	if *typeName == "" {
+		*typeName = uixml.GoName(base) + "UI"
+	}
+	if *pkgName == "" {
+		*pkgName = "main"
+	}
+	if *output == "" {
+		*output = filepath.Join(filepath.Dir(file), base+"_ui.go")
+	}
+
+	root, e := uixml.ParseFile(file)
+	if e != nil {
+		fail(e)
+	}
+	src, e := Generate(root, filepath.Base(file), *pkgName, *typeName)
+	if e != nil {
+		fail(e)
+	}
+	if e := os.WriteFile(*output, src, 0644); e != nil {
+		fail(e)
+	}
+}
+
+func fail(e error) {
+	fmt.Fprintln(os.Stderr, "uigen:", e)
+	os.Exit(1)
+}
+
+//
+//----------------------------------------------------------------[ GENERATE ]--
+
+// field defines a struct field to generate.
+type field struct {
+	name, id, typ, class string
+}
+
+// Generate returns the formatted Go source of the accessor struct.
+func Generate(root *uixml.Node, file, pkgName, typeName string) ([]byte, error) {
+	used := map[string]bool{"buildhelp": true, "grun": true}
+	names := map[string]bool{"Load": true}
+	var fields []field
+	root.Walk(func(n, _ *uixml.Node) bool {
+		id := n.Attr("id")
+		switch {
+		case n.Name == "template":
+			return false // Template children belong to the template instance.
+
+		case id == "" || (n.Name != "object" && n.Name != "menu"):
+			return true
+		}
+
+		f := field{name: uixml.UniqueName(names, uixml.GoName(id)), id: id, class: n.Attr("class")}
+		pkg, name := uixml.GoType(f.class)
+		switch {
+		case n.Name == "menu":
+			f.class, pkg, name = "GMenu", "gio", "Menu"
+		case pkg == "":
+			pkg, name = "externglib", "Object"
+		}
+		used[pkg] = true
+		f.typ = "*" + pkg + "." + name
+		fields = append(fields, f)
+		return true
+	})
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by uigen from %s. DO NOT EDIT.\n\npackage %s\n\nimport (\n", file, pkgName)
+	var paths []string
+	for pkg := range used {
+		paths = append(paths, imports[pkg])
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if path == imports["externglib"] {
+			fmt.Fprintf(&buf, "externglib ")
+		}
+		fmt.Fprintf(&buf, "%q\n", path)
+	}
+	fmt.Fprintf(&buf, ")\n\n// %s gives typed access to the objects of %s.\ntype %s struct {\n", typeName, file, typeName)
+	for _, f := range fields {
+		fmt.Fprintf(&buf, "%s %s `gtk:%q` // %s\n", f.name, f.typ, f.id, f.class)
+	}
+	fmt.Fprintf(&buf, `}
+
+// New%[1]s loads the objects of %[2]s from the builder.
+func New%[1]s(b *buildhelp.BuildHelp) (*%[1]s, grun.Errors) {
+	ui := &%[1]s{}
+	return ui, ui.Load(b)
+}
+
+// Load fills the struct with the objects of %[2]s from the builder.
+func (ui *%[1]s) Load(b *buildhelp.BuildHelp) grun.Errors { return b.Bind(ui) }
+`, typeName, file)
+
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+func TestGenerate(t *testing.T) {
+	for file, pkg := range map[string]string{
+		"basic": "buildhelp_test",
+		"misc":  "sample",
+	} {
+		root, e := uixml.ParseFile("testdata/" + file + ".ui")
+		if e != nil {
+			t.Fatal("parse error:", e)
+		}
+		have, e := Generate(root, file+".ui", pkg, uixml.GoName(file)+"UI")
+		if e != nil {
+			t.Fatal("generate error:", e)
+		}
+		expected, _ := os.ReadFile("testdata/" + file + "_ui.go.golden")
+		if string(have) != string(expected) {
+			t.Errorf("generated %s.ui does not match the golden file:\n%s", file, have)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+// Command uilint checks gtk.Builder interface files offline.
+//
+// Diagnostics are printed as file:line:col: message, and the exit code is 1 if
+// any was found:
+//
+//   duplicate ids
+//   unknown classes, properties and signals (with GIR data)
+//   invalid enum and flags values, like orientation (with GIR data)
+//   children in objects that can't hold them (known leaves without GIR data)
+//   gtk3 only elements and properties, like <packing> or stock icons
+//
+// Usage:
+//
+//   uilint [-gir /usr/share/gir-1.0] [-ns Gtk-4.0,Adw-1] file.ui...
+//
+// Without GIR files, only the checks that don't need them are done.
+//
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gtkool4/gtkelp/gir"
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+func main() {
+	dir := flag.String("gir", "", "GIR files directory (default "+gir.Dir+")")
+	namespaces := flag.String("ns", "Gtk-4.0", "GIR namespaces to load, comma separated")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: uilint [flags] file.ui...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	l := &Linter{}
+	repo, e := gir.Load(orDefault(*dir, gir.Dir), strings.Split(*namespaces, ",")...)
+	switch {
+	case e == nil:
+		l.Repo = repo
+
+	case *dir != "":
+		fmt.Fprintln(os.Stderr, "uilint:", e)
+		os.Exit(2)
+
+	default:
+		fmt.Fprintln(os.Stderr, "uilint: GIR checks disabled:", e)
+	}
+
+	found := false
+	for _, file := range flag.Args() {
+		root, e := uixml.ParseFile(file)
+		if e != nil {
+			fmt.Fprintln(os.Stderr, e)
+			found = true
+			continue
+		}
+		for _, diag := range l.Lint(root) {
+			fmt.Println(diag)
+			found = true
+		}
+	}
+	if found {
+		os.Exit(1)
+	}
+}
+
+func orDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
+
+//
+//--------------------------------------------------------------------[ LINT ]--
+
+// Diagnostics formating.
+var (
+	FmtDupID           = "duplicate id %s, first defined at %s"                     // Format: id, position
+	FmtUnknownClass    = "unknown class %s"                                         // Format: class
+	FmtUnknownProperty = "class %s has no property %s"                              // Format: class, property
+	FmtUnknownSignal   = "class %s has no signal %s"                                // Format: class, signal
+	FmtBadEnum         = "invalid %s value %q for property %s"                      // Format: enum, value, property
+	FmtBadChild        = "class %s can't hold a child %s"                           // Format: parent, child
+	FmtGtk3Property    = "property %s was removed in gtk4"                          // Format: property
+	FmtStockIcon       = "stock icon %s was removed in gtk4, use the named icon %s" // Format: stock, icon
+	FmtStockIconOther  = "stock icon %s was removed in gtk4, use a named icon"      // Format: stock
+	TxtPacking         = "<packing> was removed in gtk4, use layout properties"
+)
+
+// Containers lists classes that accept any widget as child. With GIR data,
+// classes with a widget property of ChildProperties accept a child too.
+var Containers = []string{
+	"GtkActionBar", "GtkAspectFrame", "GtkBox", "GtkCenterBox", "GtkComboBox",
+	"GtkDialog", "GtkExpander", "GtkFixed", "GtkFlowBox", "GtkFrame", "GtkGrid",
+	"GtkHeaderBar", "GtkInfoBar", "GtkListBox", "GtkListBoxRow", "GtkFlowBoxChild",
+	"GtkNotebook", "GtkOverlay", "GtkPaned", "GtkPopover", "GtkRevealer",
+	"GtkScrolledWindow", "GtkSearchBar", "GtkStack", "GtkTextView", "GtkViewport",
+	"GtkWindow", "GtkButton", "GtkMenuButton", "GtkAssistant",
+	"GtkTreeView", "GtkTreeViewColumn", "GtkCellView", "GtkIconView", "GtkEntryCompletion",
+}
+
+// ChildProperties lists the widget properties set by a <child> element.
+var ChildProperties = []string{
+	"child", "titlebar", "start-child", "end-child", "start-widget", "center-widget",
+	"end-widget", "label-widget", "title-widget",
+}
+
+// Leaves lists widget classes that can't hold a child widget, checked without
+// GIR data.
+var Leaves = []string{
+	"GtkLabel", "GtkImage", "GtkPicture", "GtkEntry", "GtkPasswordEntry", "GtkSearchEntry",
+	"GtkSpinButton", "GtkText", "GtkEditableLabel", "GtkSeparator", "GtkSpinner",
+	"GtkProgressBar", "GtkLevelBar", "GtkSwitch", "GtkScale", "GtkScrollbar", "GtkCalendar",
+	"GtkDrawingArea", "GtkGLArea", "GtkVideo",
+}
+
+// ChildClasses lists classes accepted as child by any widget.
+var ChildClasses = []string{"GtkEventController", "GtkLayoutManager", "GtkShortcut"}
+
+// Gtk3Properties lists properties removed in gtk4.
+var Gtk3Properties = []string{
+	"always-show-image", "app-paintable", "border-width", "double-buffered",
+	"events", "image", "no-show-all", "relief", "shadow-type", "stock", "use-stock",
+}
+
+// Diagnostic is an issue found in an interface file.
+type Diagnostic struct {
+	Pos uixml.Pos
+	Msg string
+}
+
+func (d Diagnostic) String() string { return d.Pos.String() + ": " + d.Msg }
+
+// Linter checks interface files. Without Repo, checks that need GIR data are
+// skipped.
+type Linter struct {
+	Repo *gir.Repository
+}
+
+// Lint returns the issues found in the interface, in document order.
+func (l *Linter) Lint(root *uixml.Node) (list []Diagnostic) {
+	report := func(pos uixml.Pos, format string, args ...interface{}) {
+		list = append(list, Diagnostic{Pos: pos, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	ids := map[string]uixml.Pos{}
+	root.Walk(func(n, parent *uixml.Node) bool {
+		if id := n.Attr("id"); id != "" && n.Name != "property" {
+			if first, ok := ids[id]; ok {
+				report(n.Pos, FmtDupID, id, first)
+			} else {
+				ids[id] = n.Pos
+			}
+		}
+
+		switch n.Name {
+		case "menu":
+			return false // Menu models are not objects.
+
+		case "packing":
+			report(n.Pos, TxtPacking)
+
+		case "object", "template":
+			l.lintObject(n, report)
+
+		case "property":
+			l.lintProperty(n, parent, report)
+
+		case "signal":
+			class := className(parent)
+			if l.known(class) && !l.Repo.Signal(class, n.Attr("name")) {
+				report(n.Pos, FmtUnknownSignal, class, n.Attr("name"))
+			}
+
+		case "child":
+			l.lintChild(n, parent, report)
+		}
+		return true
+	})
+	return list
+}
+
+type reportFunc func(pos uixml.Pos, format string, args ...interface{})
+
+func (l *Linter) lintObject(n *uixml.Node, report reportFunc) {
+	for _, class := range []string{n.Attr("class"), n.Attr("parent")} {
+		if class == "" || (n.Name == "template" && class == n.Attr("class")) {
+			continue // The template class is defined by the interface.
+		}
+		if l.Repo != nil && l.Repo.Class(class) == nil {
+			report(n.Pos, FmtUnknownClass, class)
+		}
+	}
+}
+
+func (l *Linter) lintProperty(n, parent *uixml.Node, report reportFunc) {
+	if parent == nil || (parent.Name != "object" && parent.Name != "template") {
+		return
+	}
+	name := strings.ReplaceAll(n.Attr("name"), "_", "-")
+	value := strings.TrimSpace(n.Text)
+	if name == "icon-name" && strings.HasPrefix(value, "gtk-") {
+		if icon, ok := uixml.StockIcons[value]; ok {
+			report(n.Pos, FmtStockIcon, value, icon)
+		} else {
+			report(n.Pos, FmtStockIconOther, value)
+		}
+	}
+
+	class := className(parent)
+	if !l.known(class) {
+		if l.Repo == nil && contains(Gtk3Properties, name) {
+			report(n.Pos, FmtGtk3Property, name)
+		}
+		return
+	}
+
+	prop := l.Repo.Property(class, name)
+	switch {
+	case prop == nil && contains(Gtk3Properties, name):
+		report(n.Pos, FmtGtk3Property, name)
+
+	case prop == nil:
+		report(n.Pos, FmtUnknownProperty, class, name)
+
+	case l.Repo.Enums[prop.Type] != nil && !n.HasAttr("bind-source") && !l.Repo.Enums[prop.Type].Valid(value):
+		report(n.Pos, FmtBadEnum, prop.Type, value, name)
+	}
+}
+
+// lintChild reports widgets added to classes that can't hold them. Without
+// GIR data, only known widgets added to Leaves are reported.
+func (l *Linter) lintChild(n, parent *uixml.Node, report reportFunc) {
+	class := className(parent)
+	if n.HasAttr("internal-child") {
+		return
+	}
+	for _, child := range n.Elements("object") {
+		childClass := child.Attr("class")
+		switch {
+		case l.Repo == nil:
+			isWidget := contains(Leaves, childClass) || contains(Containers, childClass)
+			if !contains(Leaves, class) || !isWidget {
+				continue
+			}
+
+		case !l.known(class), l.Repo.Class(childClass) == nil,
+			l.isA(class, Containers), l.hasChildProperty(class), l.isA(childClass, ChildClasses):
+			continue
+		}
+		report(child.Pos, FmtBadChild, class, childClass)
+	}
+}
+
+// hasChildProperty returns true if the class has a writable widget property of
+// ChildProperties.
+func (l *Linter) hasChildProperty(class string) bool {
+	for _, name := range ChildProperties {
+		if p := l.Repo.Property(class, name); p != nil && p.Writable && l.Repo.IsA(p.Type, "GtkWidget") {
+			return true
+		}
+	}
+	return false
+}
+
+// known returns true if the class can be checked with GIR data.
+func (l *Linter) known(class string) bool {
+	return l.Repo != nil && l.Repo.Class(class) != nil
+}
+
+// isA returns true if the class inherits one of the list.
+func (l *Linter) isA(class string, list []string) bool {
+	for _, ancestor := range list {
+		if l.Repo.IsA(class, ancestor) {
+			return true
+		}
+	}
+	return false
+}
+
+// className returns the class of an object, or the parent class of a template.
+func className(n *uixml.Node) string {
+	if n == nil {
+		return ""
+	}
+	if n.Name == "template" {
+		return n.Attr("parent")
+	}
+	return n.Attr("class")
+}
+
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gtkool4/gtkelp/gir"
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+func TestLint(t *testing.T) {
+	repo, e := gir.Load("../../gir/testdata", "Gtk-4.0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	testLint(t, &Linter{Repo: repo}, "testdata/lint.ui", `
+testdata/lint.ui:4:5: invalid GtkOrientation value "diagonal" for property orientation
+testdata/lint.ui:6:5: property border-width was removed in gtk4
+testdata/lint.ui:10:9: stock icon gtk-copy was removed in gtk4, use the named icon edit-copy
+testdata/lint.ui:12:9: class GtkButton has no property wrap
+testdata/lint.ui:15:9: class GtkButton has no signal toggled
+testdata/lint.ui:20:7: <packing> was removed in gtk4, use layout properties
+testdata/lint.ui:25:7: duplicate id ok, first defined at testdata/lint.ui:8:7
+testdata/lint.ui:28:11: class GtkLabel can't hold a child GtkButton
+testdata/lint.ui:33:7: unknown class GtkNope
+`)
+}
+
+func TestLintWithoutGIR(t *testing.T) {
+	testLint(t, &Linter{}, "../../buildhelp/example_test.ui", `
+../../buildhelp/example_test.ui:16:13: stock icon gtk-copy was removed in gtk4, use the named icon edit-copy
+../../buildhelp/example_test.ui:23:13: stock icon gtk-paste was removed in gtk4, use the named icon edit-paste
+../../buildhelp/example_test.ui:35:13: stock icon gtk-quit was removed in gtk4, use the named icon application-exit
+`)
+	testLint(t, &Linter{}, "testdata/lint.ui", `
+testdata/lint.ui:6:5: property border-width was removed in gtk4
+testdata/lint.ui:10:9: stock icon gtk-copy was removed in gtk4, use the named icon edit-copy
+testdata/lint.ui:20:7: <packing> was removed in gtk4, use layout properties
+testdata/lint.ui:25:7: duplicate id ok, first defined at testdata/lint.ui:8:7
+testdata/lint.ui:28:11: class GtkLabel can't hold a child GtkButton
+`)
+}
+
+func testLint(t *testing.T, l *Linter, file, expected string) {
+	root, e := uixml.ParseFile(file)
+	if e != nil {
+		t.Fatal(e)
+	}
+	var lines []string
+	for _, diag := range l.Lint(root) {
+		lines = append(lines, diag.String())
+	}
+	have := strings.Join(lines, "\n")
+	if have != strings.TrimSpace(expected) {
+		t.Errorf("lint of %s should report:\n%s\nbut we have:\n%s", file, strings.TrimSpace(expected), have)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+// RenamedClasses gives the gtk4 class replacing gtk3 classes, and whether
+// they were vertical.
+var RenamedClasses = map[string]struct {
+	Class    string
+	Vertical bool
+}{
+	"GtkHBox":        {"GtkBox", false},
+	"GtkVBox":        {"GtkBox", true},
+	"GtkButtonBox":   {"GtkBox", false},
+	"GtkHButtonBox":  {"GtkBox", false},
+	"GtkVButtonBox":  {"GtkBox", true},
+	"GtkHPaned":      {"GtkPaned", false},
+	"GtkVPaned":      {"GtkPaned", true},
+	"GtkHScale":      {"GtkScale", false},
+	"GtkVScale":      {"GtkScale", true},
+	"GtkHScrollbar":  {"GtkScrollbar", false},
+	"GtkVScrollbar":  {"GtkScrollbar", true},
+	"GtkHSeparator":  {"GtkSeparator", false},
+	"GtkVSeparator":  {"GtkSeparator", true},
+	"GtkRadioButton": {"GtkCheckButton", false},
+}
+
+// RemovedClasses lists gtk3 classes without replacement class, with a hint.
+var RemovedClasses = map[string]string{
+	"GtkAction":            "GAction",
+	"GtkActionGroup":       "GActionGroup",
+	"GtkArrow":             "a GtkImage with an icon",
+	"GtkCheckMenuItem":     "a menu model item",
+	"GtkColorSelection":    "GtkColorChooserWidget",
+	"GtkFileChooserButton": "a GtkButton opening a GtkFileChooserNative",
+	"GtkFontSelection":     "GtkFontChooserWidget",
+	"GtkHandleBox":         "",
+	"GtkImageMenuItem":     "a menu model item",
+	"GtkInvisible":         "",
+	"GtkLayout":            "GtkFixed",
+	"GtkMenu":              "GtkPopoverMenu with a menu model",
+	"GtkMenuBar":           "GtkPopoverMenuBar with a menu model",
+	"GtkMenuItem":          "a menu model item",
+	"GtkMenuToolButton":    "GtkMenuButton",
+	"GtkOffscreenWindow":   "",
+	"GtkPlug":              "",
+	"GtkRadioAction":       "GAction",
+	"GtkRadioMenuItem":     "a menu model item",
+	"GtkSeparatorMenuItem": "a menu model section",
+	"GtkSeparatorToolItem": "GtkSeparator",
+	"GtkSocket":            "",
+	"GtkStatusIcon":        "",
+	"GtkTable":             "GtkGrid",
+	"GtkTearoffMenuItem":   "",
+	"GtkToggleAction":      "GAction",
+	"GtkToggleToolButton":  "GtkToggleButton",
+	"GtkToolButton":        "GtkButton",
+	"GtkToolItem":          "",
+	"GtkToolbar":           "a GtkBox with the toolbar style class",
+	"GtkUIManager":         "menu models",
+}
+
+// UnwrappedClasses lists removed containers replaced by their child.
+var UnwrappedClasses = []string{"GtkAlignment", "GtkEventBox"}
+
+// WidgetProperties lists the properties moved from unwrapped containers to
+// their child.
+var WidgetProperties = []string{
+	"halign", "valign", "hexpand", "vexpand", "margin-start", "margin-end",
+	"margin-top", "margin-bottom", "visible", "sensitive", "tooltip-text",
+	"tooltip-markup", "width-request", "height-request",
+}
+
+// DroppedProperties lists gtk3 properties removed without effect in gtk4.
+var DroppedProperties = []string{
+	"above-child", "always-show-image", "app-paintable", "can-default",
+	"double-buffered", "draw-indicator", "events", "has-default", "has-focus",
+	"has-resize-grip", "has-subtitle", "image-position", "no-show-all",
+	"resize-mode", "role", "skip-pager-hint", "skip-taskbar-hint", "type-hint",
+	"urgency-hint", "use-stock", "visible-window", "window-position",
+}
+
+// RemovedProperties gives hints for gtk3 properties that can't be converted,
+// by class.property or property.
+var RemovedProperties = map[string]string{
+	"GtkHeaderBar.subtitle": "title-widget",
+	"GtkHeaderBar.title":    "title-widget",
+	"GtkImage.pixbuf":       "file or resource",
+	"icon":                  "icon-name",
+	"image":                 "child",
+}
+
+// RenamedProperties gives the gtk4 name of renamed properties.
+var RenamedProperties = map[string]string{
+	"margin-left":       "margin-start",
+	"margin-right":      "margin-end",
+	"show-close-button": "show-title-buttons",
+}
+
+// RemovedSignals lists gtk3 signals removed in gtk4, besides the *-event ones.
+var RemovedSignals = []string{
+	"composited-changed", "drag-begin", "drag-data-delete", "drag-data-get",
+	"drag-data-received", "drag-drop", "drag-end", "drag-failed", "drag-leave",
+	"drag-motion", "draw", "grab-notify", "hierarchy-changed", "parent-set",
+	"popup-menu", "screen-changed", "size-allocate", "style-set", "style-updated",
+}
+
+// StockLabels gives the label of stock buttons.
+var StockLabels = map[string]string{
+	"gtk-add": "_Add", "gtk-apply": "_Apply", "gtk-cancel": "_Cancel",
+	"gtk-close": "_Close", "gtk-delete": "_Delete", "gtk-help": "_Help",
+	"gtk-no": "_No", "gtk-ok": "_OK", "gtk-open": "_Open", "gtk-quit": "_Quit",
+	"gtk-remove": "_Remove", "gtk-save": "_Save", "gtk-yes": "_Yes",
+}
+
+// GridLayout gives the gtk4 layout property of GtkGrid packing properties.
+var GridLayout = map[string]string{
+	"left-attach": "column",
+	"top-attach":  "row",
+	"width":       "column-span",
+	"height":      "row-span",
+}
+
+// StackPage lists the GtkStack packing properties moved to GtkStackPage.
+var StackPage = []string{"name", "title", "icon-name", "needs-attention"}
+
+// Relations gives the gtk4 accessible relation of ATK relations.
+var Relations = map[string]string{
+	"labelled-by":    "labelled-by",
+	"described-by":   "described-by",
+	"controller-for": "controls",
+	"flows-to":       "flow-to",
+}
+
+// InternalChildren lists the internal children kept in gtk4.
+var InternalChildren = []string{"content_area", "entry", "headerbar", "message_area", "selection"}
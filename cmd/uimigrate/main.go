@@ -0,0 +1,747 @@
+// Command uimigrate rewrites gtk3 gtk.Builder files, like old Glade files, to
+// gtk4:
+//
+//   <packing> properties become layout or child properties
+//   GtkBox children packed at the end are moved last, or typed end in bars
+//   stock icons and stock buttons use named icons and labels
+//   GtkEventBox and GtkAlignment are replaced by their child
+//   GtkHBox, GtkVBox and other oriented classes become GtkBox...
+//   dialog vbox and action_area internal children, and ATK accessibility
+//   removed properties and event signals are dropped
+//
+// Anything that could not be converted automatically is reported as
+// file:line:col: message, with positions in the gtk3 file, and the exit code
+// is 1.
+//
+// Usage:
+//
+//   uimigrate [-w | -o new.ui] old.ui...
+//
+// Without -w or -o, the result is written to the standard output. XML comments
+// are not kept.
+//
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+func main() {
+	write := flag.Bool("w", false, "write the result to the source files")
+	output := flag.String("o", "", "output file, for a single source file (default: standard output)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: uimigrate [flags] file.ui...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 || (!*write && flag.NArg() > 1) {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	found := false
+	for _, file := range flag.Args() {
+		root, e := uixml.ParseFile(file)
+		if e != nil {
+			fmt.Fprintln(os.Stderr, e)
+			os.Exit(1)
+		}
+		for _, diag := range Migrate(root) {
+			fmt.Fprintln(os.Stderr, diag)
+			found = true
+		}
+
+		switch {
+		case *write:
+			e = os.WriteFile(file, root.Bytes(), 0644)
+		case *output != "":
+			e = os.WriteFile(*output, root.Bytes(), 0644)
+		default:
+			_, e = root.WriteTo(os.Stdout)
+		}
+		if e != nil {
+			fmt.Fprintln(os.Stderr, "uimigrate:", e)
+			os.Exit(1)
+		}
+	}
+	if found {
+		os.Exit(1)
+	}
+}
+
+//
+//-----------------------------------------------------------------[ MIGRATE ]--
+
+// Report formating.
+var (
+	FmtAccessible      = "accessible %s %s has no gtk4 equivalent"                             // Format: element, name
+	FmtBoxEnd          = "children packed at the end of %s are now last, add an expanding child" // Format: id or class
+	FmtClass           = "class %s was removed in gtk4"                                         // Format: class
+	FmtClassHint       = "class %s was removed in gtk4, use %s"                                 // Format: class, replacement
+	FmtInternalChild   = "internal child %s of %s was not converted"                            // Format: name, class
+	FmtPacking         = "packing property %s of %s has no gtk4 equivalent"                     // Format: property, class
+	FmtProperty        = "property %s=%s has no gtk4 equivalent"                                // Format: property, value
+	FmtPropertyHint    = "property %s was removed in gtk4, use %s"                              // Format: property, replacement
+	FmtSignal          = "signal %s was removed in gtk4, use an event controller"               // Format: signal
+	FmtSignalRenamed   = "signal delete-event is close-request in gtk4, check the handler %s"   // Format: handler
+	FmtStockIcon       = "stock icon %s has no named icon"                                      // Format: stock
+	FmtUnwrapped       = "%s %s was removed, its child takes its place"                         // Format: class, id
+	TxtAccelerator     = "<accelerator> was removed in gtk4, use shortcuts or application accels"
+	TxtUnwrappedNoSlot = "removed container can only be replaced when it is a child"
+)
+
+// Diagnostic is an element that could not be converted automatically.
+type Diagnostic struct {
+	Pos uixml.Pos
+	Msg string
+}
+
+func (d Diagnostic) String() string { return d.Pos.String() + ": " + d.Msg }
+
+// Migrate rewrites the gtk3 interface to gtk4, and returns what could not be
+// converted, in document order.
+func Migrate(root *uixml.Node) []Diagnostic {
+	m := &migration{}
+	m.node(root)
+	sort.SliceStable(m.list, func(i, j int) bool {
+		a, b := m.list[i].Pos, m.list[j].Pos
+		return a.Line < b.Line || (a.Line == b.Line && a.Col < b.Col)
+	})
+	return m.list
+}
+
+type migration struct {
+	list []Diagnostic
+}
+
+func (m *migration) report(pos uixml.Pos, format string, args ...interface{}) {
+	m.list = append(m.list, Diagnostic{Pos: pos, Msg: fmt.Sprintf(format, args...)})
+}
+
+// node migrates the element and its descendants.
+func (m *migration) node(n *uixml.Node) {
+	switch n.Name {
+	case "requires":
+		if n.Attr("lib") == "gtk+" {
+			n.SetAttr("lib", "gtk")
+			n.SetAttr("version", "4.0")
+		}
+
+	case "menu":
+		return // Menu models didn't change.
+
+	case "object", "template":
+		if class := n.Attr("class"); contains(UnwrappedClasses, class) || contains(UnwrappedClasses, n.Attr("parent")) {
+			m.report(n.Pos, FmtClass+": "+TxtUnwrappedNoSlot, class)
+		}
+		m.object(n)
+		return
+	}
+	for _, c := range n.Children {
+		m.node(c)
+	}
+}
+
+// object migrates the object or template: class, properties, signals and
+// children.
+func (m *migration) object(n *uixml.Node) {
+	class := m.class(n)
+	useStock := isTrue(propertyValue(n, "use-stock"))
+	var defaults []string // Property name, value pairs set if not defined.
+	set := func(name, value string) { defaults = append(defaults, name, value) }
+
+	var kept, ends []*uixml.Node
+	index := 0 // Of children.
+	for _, c := range n.Children {
+		keep := c
+		switch c.Name {
+		case "property":
+			if !m.property(class, c, useStock, set) {
+				keep = nil
+			}
+			m.node(c)
+
+		case "signal":
+			if !m.signal(c) {
+				keep = nil
+			}
+
+		case "accelerator":
+			m.report(c.Pos, TxtAccelerator)
+			keep = nil
+
+		case "accessibility":
+			m.accessibility(c)
+
+		case "child":
+			var end bool
+			keep, end = m.child(n, class, c, index, set)
+			index++
+			if end {
+				ends = append(ends, keep)
+				keep = nil
+			}
+
+		default:
+			m.node(c)
+		}
+		if keep != nil {
+			kept = append(kept, keep)
+		}
+	}
+	for i := len(ends) - 1; i >= 0; i-- { // The first end child was the last.
+		kept = append(kept, ends[i])
+	}
+	n.Children = mergeAccessibility(kept)
+	for i := 0; i < len(defaults); i += 2 {
+		setDefault(n, defaults[i], defaults[i+1])
+	}
+
+	if len(ends) > 0 && len(ends) < index && !m.expands(n) {
+		name := n.Attr("id")
+		if name == "" {
+			name = class
+		}
+		m.report(n.Pos, FmtBoxEnd, name)
+	}
+	if strings.HasPrefix(class, "Gtk") && strings.HasSuffix(class, "Dialog") {
+		m.actionArea(n)
+	}
+}
+
+// class renames the class of the object, or reports it if it was removed.
+// Returns the gtk4 class, the parent for templates.
+func (m *migration) class(n *uixml.Node) string {
+	attr := "class"
+	if n.Name == "template" {
+		attr = "parent"
+	}
+	class := n.Attr(attr)
+	if renamed, ok := RenamedClasses[class]; ok {
+		n.SetAttr(attr, renamed.Class)
+		if renamed.Vertical {
+			setDefault(n, "orientation", "vertical")
+		}
+		return renamed.Class
+	}
+	if hint, ok := RemovedClasses[class]; ok {
+		if hint == "" {
+			m.report(n.Pos, FmtClass, class)
+		} else {
+			m.report(n.Pos, FmtClassHint, class, hint)
+		}
+	}
+	return class
+}
+
+// property converts the property of the class, and returns false if it must
+// be dropped. Properties replacing it are given to set.
+func (m *migration) property(class string, p *uixml.Node, useStock bool, set func(name, value string)) bool {
+	name := propertyName(p)
+	value := strings.TrimSpace(p.Text)
+	if renamed, ok := RenamedProperties[name]; ok {
+		p.SetAttr("name", renamed)
+		return true
+	}
+	switch name {
+	case "icon-name", "stock", "primary-icon-stock", "secondary-icon-stock":
+		if name == "icon-name" && !strings.HasPrefix(value, "gtk-") {
+			return true
+		}
+		icon, ok := uixml.StockIcons[value]
+		if !ok {
+			m.report(p.Pos, FmtStockIcon, value)
+			return false
+		}
+		if name != "icon-name" {
+			name = strings.TrimSuffix(name, "stock") + "name"
+			if name == "name" {
+				name = "icon-name"
+			}
+			p.SetAttr("name", name)
+		}
+		p.Text = icon
+		return true
+
+	case "label":
+		if !useStock {
+			return true
+		}
+		if label, ok := StockLabels[value]; ok {
+			p.Text = label
+			p.SetAttr("translatable", "yes")
+			set("use-underline", "True")
+			return true
+		}
+		if icon, ok := uixml.StockIcons[value]; ok {
+			set("icon-name", icon)
+			return false
+		}
+		m.report(p.Pos, FmtStockIcon, value)
+		return false
+
+	case "border-width", "margin":
+		set("margin-start", value)
+		set("margin-end", value)
+		set("margin-top", value)
+		set("margin-bottom", value)
+		return false
+
+	case "xpad", "ypad":
+		if value != "0" && name == "xpad" {
+			set("margin-start", value)
+			set("margin-end", value)
+		} else if value != "0" {
+			set("margin-top", value)
+			set("margin-bottom", value)
+		}
+		return false
+
+	case "relief":
+		if isNick(value, "none") {
+			set("has-frame", "False")
+		}
+		return false
+
+	case "shadow-type":
+		switch {
+		case class == "GtkScrolledWindow":
+			set("has-frame", strconv.FormatBool(!isNick(value, "none")))
+		case isNick(value, "none"):
+			m.report(p.Pos, FmtProperty, name, value)
+		}
+		return false
+
+	case "icon-size":
+		if size, e := strconv.Atoi(value); e == nil {
+			p.Text = "normal"
+			if size >= 5 { // Drag and drop, dialog.
+				p.Text = "large"
+			}
+		}
+		return true
+
+	case "layout-style":
+		if !isNick(value, "start") {
+			m.report(p.Pos, FmtProperty, name, value)
+		}
+		return false
+	}
+
+	if hint, ok := RemovedProperties[class+"."+name]; ok {
+		m.report(p.Pos, FmtPropertyHint, name, hint)
+		return false
+	}
+	if hint, ok := RemovedProperties[name]; ok {
+		m.report(p.Pos, FmtPropertyHint, name, hint)
+		return false
+	}
+	return !contains(DroppedProperties, name)
+}
+
+// signal renames delete-event or reports removed signals, and returns false
+// if it must be dropped.
+func (m *migration) signal(s *uixml.Node) bool {
+	name := strings.ReplaceAll(s.Attr("name"), "_", "-")
+	switch {
+	case name == "delete-event":
+		s.SetAttr("name", "close-request")
+		m.report(s.Pos, FmtSignalRenamed, s.Attr("handler"))
+
+	case strings.HasSuffix(name, "-event") || contains(RemovedSignals, name):
+		m.report(s.Pos, FmtSignal, name)
+		return false
+	}
+	return true
+}
+
+// child migrates the child of the object of the class, at the index, and
+// returns the element replacing it or nil, and true if it is packed at the end
+// of a box. Properties of the parent are given to set.
+func (m *migration) child(parent *uixml.Node, class string, c *uixml.Node, index int, set func(name, value string)) (*uixml.Node, bool) {
+	var obj, packing *uixml.Node
+	var kept []*uixml.Node
+	for _, n := range c.Children {
+		switch n.Name {
+		case "object":
+			m.object(n)
+			if contains(UnwrappedClasses, n.Attr("class")) {
+				n = m.unwrap(n)
+			}
+			obj = n
+
+		case "packing":
+			packing = n
+			n = nil
+
+		default:
+			m.node(n)
+		}
+		if n != nil {
+			kept = append(kept, n)
+		}
+	}
+	c.Children = kept
+	if obj == nil && len(kept) == 0 {
+		return nil, false // Empty container removed.
+	}
+
+	switch internal := c.Attr("internal-child"); {
+	case internal == "accessible" && obj != nil:
+		return m.accessible(obj), false
+
+	case internal == "vbox" && strings.HasSuffix(class, "Dialog"):
+		c.SetAttr("internal-child", "content_area")
+
+	case internal == "action_area" && class == "GtkBox": // Moved by the dialog.
+
+	case internal != "" && !contains(InternalChildren, internal):
+		m.report(c.Pos, FmtInternalChild, internal, class)
+	}
+
+	if packing == nil || obj == nil {
+		return c, false
+	}
+	return c, m.packing(parent, class, c, obj, packing, index, set)
+}
+
+// unwrap returns the child object replacing the removed container, with its
+// layout properties, or nil if it has no child.
+func (m *migration) unwrap(obj *uixml.Node) *uixml.Node {
+	class := obj.Attr("class")
+	if id := obj.Attr("id"); id != "" {
+		m.report(obj.Pos, FmtUnwrapped, class, id)
+	}
+	var inner *uixml.Node
+	for _, c := range obj.Elements("child") {
+		for _, o := range c.Elements("object") {
+			inner = o
+		}
+	}
+	if inner == nil {
+		return nil
+	}
+
+	if class == "GtkAlignment" {
+		for _, axis := range []struct{ align, scale, property string }{
+			{"xalign", "xscale", "halign"},
+			{"yalign", "yscale", "valign"},
+		} {
+			if align := alignment(propertyValue(obj, axis.align), propertyValue(obj, axis.scale)); align != "" {
+				setDefault(inner, axis.property, align)
+			}
+		}
+		for _, side := range []struct{ padding, margin string }{
+			{"left-padding", "margin-start"},
+			{"right-padding", "margin-end"},
+			{"top-padding", "margin-top"},
+			{"bottom-padding", "margin-bottom"},
+		} {
+			if padding := propertyValue(obj, side.padding); padding != "" && padding != "0" {
+				setDefault(inner, side.margin, padding)
+			}
+		}
+	}
+	for _, name := range WidgetProperties {
+		if p := property(obj, name); p != nil && property(inner, name) == nil {
+			addProperty(inner, p.Copy())
+		}
+	}
+	return inner
+}
+
+// alignment returns the align value of a GtkAlignment axis, or an empty
+// string to fill.
+func alignment(align, scale string) string {
+	if s, e := strconv.ParseFloat(scale, 64); e != nil || s >= 1 {
+		return ""
+	}
+	a, e := strconv.ParseFloat(align, 64)
+	switch {
+	case e != nil:
+		return "center" // Default 0.5.
+	case a <= 0.25:
+		return "start"
+	case a >= 0.75:
+		return "end"
+	}
+	return "center"
+}
+
+// packing converts the packing properties of the child object, at the index
+// of the parent class, and returns true if it is packed at the end of a box.
+// Properties of the parent are given to set.
+func (m *migration) packing(parent *uixml.Node, class string, c, obj, packing *uixml.Node, index int, set func(name, value string)) (end bool) {
+	vertical := isNick(propertyValue(parent, "orientation"), "vertical")
+	expand, fill, padding := false, true, ""
+	layout := &uixml.Node{Name: "layout", Pos: packing.Pos}
+	page := &uixml.Node{Name: "object", Pos: packing.Pos}
+	for _, p := range packing.Elements("property") {
+		name, value := propertyName(p), strings.TrimSpace(p.Text)
+		switch {
+		case name == "position", class == "GtkBox" && name == "non-homogeneous": // Children are in order.
+
+		case name == "pack-type" && class == "GtkBox":
+			end = isNick(value, "end")
+
+		case name == "pack-type" && (class == "GtkHeaderBar" || class == "GtkActionBar"):
+			if isNick(value, "end") {
+				c.SetAttr("type", "end")
+			}
+
+		case class == "GtkBox" && name == "expand":
+			expand = isTrue(value)
+
+		case class == "GtkBox" && name == "fill":
+			fill = isTrue(value)
+
+		case class == "GtkBox" && name == "padding":
+			padding = value
+
+		case class == "GtkGrid" && GridLayout[name] != "":
+			p.SetAttr("name", GridLayout[name])
+			layout.Append(p)
+
+		case class == "GtkPaned" && (name == "resize" || name == "shrink"):
+			side := "start"
+			if index > 0 {
+				side = "end"
+			}
+			set(name+"-"+side+"-child", value)
+
+		case class == "GtkStack" && contains(StackPage, name):
+			p.SetAttr("name", name)
+			page.Append(p)
+
+		default:
+			m.report(p.Pos, FmtPacking, name, class)
+		}
+	}
+
+	if expand {
+		axis := "h"
+		if vertical {
+			axis = "v"
+		}
+		setDefault(obj, axis+"expand", "True")
+		if !fill {
+			setDefault(obj, axis+"align", "center")
+		}
+	}
+	if padding != "" && padding != "0" {
+		sides := []string{"margin-start", "margin-end"}
+		if vertical {
+			sides = []string{"margin-top", "margin-bottom"}
+		}
+		for _, side := range sides {
+			setDefault(obj, side, padding)
+		}
+	}
+	if len(layout.Children) > 0 {
+		obj.Append(layout)
+	}
+	if len(page.Children) > 0 {
+		page.SetAttr("class", "GtkStackPage")
+		page.Append(uixml.New("property", "name", "child").Append(obj))
+		for i, n := range c.Children {
+			if n == obj {
+				c.Children[i] = page
+			}
+		}
+	}
+	return end
+}
+
+// expands returns true if a child object of the box expands in its
+// orientation.
+func (m *migration) expands(box *uixml.Node) bool {
+	name := "hexpand"
+	if isNick(propertyValue(box, "orientation"), "vertical") {
+		name = "vexpand"
+	}
+	for _, c := range box.Elements("child") {
+		for _, obj := range c.Elements("object") {
+			if isTrue(propertyValue(obj, name)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// actionArea moves the children of the gtk3 dialog action area to action
+// children of the dialog.
+func (m *migration) actionArea(dialog *uixml.Node) {
+	var actions []*uixml.Node
+	for _, c := range dialog.Elements("child") {
+		if c.Attr("internal-child") != "content_area" {
+			continue
+		}
+		for _, box := range c.Elements("object") {
+			var kept []*uixml.Node
+			for _, n := range box.Children {
+				if n.Name != "child" || n.Attr("internal-child") != "action_area" {
+					kept = append(kept, n)
+					continue
+				}
+				for _, area := range n.Elements("object") {
+					for _, ac := range area.Elements("child") {
+						for _, obj := range ac.Elements("object") {
+							child := uixml.New("child", "type", "action").Append(obj)
+							child.Pos = ac.Pos
+							actions = append(actions, child)
+						}
+					}
+				}
+			}
+			box.Children = kept
+		}
+	}
+	dialog.Append(actions...)
+}
+
+// accessible converts the ATK object of the gtk3 accessible internal child to
+// a gtk4 accessibility element.
+func (m *migration) accessible(atk *uixml.Node) *uixml.Node {
+	a := &uixml.Node{Name: "accessibility", Pos: atk.Pos}
+	for _, p := range atk.Elements("property") {
+		switch strings.TrimPrefix(propertyName(p), "AtkObject::") {
+		case "accessible-name":
+			p.SetAttr("name", "label")
+			a.Append(p)
+
+		case "accessible-description":
+			p.SetAttr("name", "description")
+			a.Append(p)
+
+		default:
+			m.report(p.Pos, FmtAccessible, "property", p.Attr("name"))
+		}
+	}
+	if len(a.Children) == 0 {
+		return nil
+	}
+	return a
+}
+
+// accessibility converts gtk3 ATK relations of the element to gtk4.
+func (m *migration) accessibility(a *uixml.Node) {
+	var kept []*uixml.Node
+	for _, n := range a.Children {
+		switch {
+		case n.Name == "relation" && n.HasAttr("type"):
+			relation, ok := Relations[n.Attr("type")]
+			if !ok {
+				m.report(n.Pos, FmtAccessible, "relation", n.Attr("type"))
+				continue
+			}
+			converted := uixml.New("relation", "name", relation)
+			converted.Pos, converted.Text = n.Pos, n.Attr("target")
+			n = converted
+
+		case n.Name == "action":
+			m.report(n.Pos, FmtAccessible, "action", n.Attr("action_name"))
+			continue
+		}
+		kept = append(kept, n)
+	}
+	a.Children = kept
+}
+
+// mergeAccessibility merges the accessibility elements of the list in the
+// first one.
+func mergeAccessibility(list []*uixml.Node) []*uixml.Node {
+	var first *uixml.Node
+	var kept []*uixml.Node
+	for _, n := range list {
+		switch {
+		case n.Name != "accessibility":
+		case first == nil:
+			first = n
+		default:
+			first.Append(n.Children...)
+			continue
+		}
+		kept = append(kept, n)
+	}
+	return kept
+}
+
+//
+//-----------------------------------------------------------------[ HELPERS ]--
+
+// propertyName returns the name of the property element, with dashes.
+func propertyName(p *uixml.Node) string {
+	return strings.ReplaceAll(p.Attr("name"), "_", "-")
+}
+
+// property returns the property element of the object, or nil.
+func property(obj *uixml.Node, name string) *uixml.Node {
+	for _, p := range obj.Elements("property") {
+		if propertyName(p) == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// propertyValue returns the value of the object property, or an empty string.
+func propertyValue(obj *uixml.Node, name string) string {
+	if p := property(obj, name); p != nil {
+		return strings.TrimSpace(p.Text)
+	}
+	return ""
+}
+
+// setDefault sets the object property if it isn't defined.
+func setDefault(obj *uixml.Node, name, value string) {
+	if property(obj, name) == nil {
+		p := uixml.New("property", "name", name)
+		p.Text = value
+		addProperty(obj, p)
+	}
+}
+
+// addProperty adds the property element after the other properties of the
+// object.
+func addProperty(obj, p *uixml.Node) {
+	i := 0
+	for j, n := range obj.Children {
+		if n.Name == "property" {
+			i = j + 1
+		}
+	}
+	obj.Children = append(obj.Children[:i], append([]*uixml.Node{p}, obj.Children[i:]...)...)
+}
+
+// isTrue returns true if the text is a true boolean for gtk.Builder.
+func isTrue(text string) bool {
+	switch strings.ToLower(text) {
+	case "1", "t", "true", "y", "yes":
+		return true
+	}
+	return false
+}
+
+// isNick returns true if the enum value is the nick, like vertical or
+// GTK_ORIENTATION_VERTICAL.
+func isNick(value, nick string) bool {
+	value = strings.ToLower(strings.ReplaceAll(value, "_", "-"))
+	return value == nick || strings.HasSuffix(value, "-"+nick)
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
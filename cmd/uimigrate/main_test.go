@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+func TestMigrate(t *testing.T) {
+	root, e := uixml.ParseFile("testdata/gtk3.ui")
+	if e != nil {
+		t.Fatal(e)
+	}
+	var lines []string
+	for _, diag := range Migrate(root) {
+		lines = append(lines, diag.String())
+	}
+	expected := strings.TrimSpace(`
+testdata/gtk3.ui:8:5: signal delete-event is close-request in gtk4, check the handler on_delete
+testdata/gtk3.ui:12:9: property title was removed in gtk4, use title-widget
+testdata/gtk3.ui:31:11: GtkEventBox eventbox was removed, its child takes its place
+testdata/gtk3.ui:34:13: signal button-press-event was removed in gtk4, use an event controller
+testdata/gtk3.ui:78:21: accessible property AtkObject::accessible-role has no gtk4 equivalent
+testdata/gtk3.ui:83:19: accessible relation label-for has no gtk4 equivalent
+testdata/gtk3.ui:95:11: class GtkToolbar was removed in gtk4, use a GtkBox with the toolbar style class
+testdata/gtk3.ui:104:13: <accelerator> was removed in gtk4, use shortcuts or application accels
+testdata/gtk3.ui:119:13: property layout-style=end has no gtk4 equivalent
+testdata/gtk3.ui:150:17: packing property tab-expand of GtkNotebook has no gtk4 equivalent
+`)
+	if have := strings.Join(lines, "\n"); have != expected {
+		t.Errorf("migration of gtk3.ui should report:\n%s\nbut we have:\n%s", expected, have)
+	}
+
+	golden, _ := os.ReadFile("testdata/gtk3.ui.golden")
+	if have := root.String(); have != string(golden) {
+		t.Errorf("migrated gtk3.ui does not match the golden file:\n%s", have)
+	}
+}
+
+func TestMigrateBoxEnd(t *testing.T) {
+	root, _ := uixml.ParseString(`<interface>
+  <object class="GtkHBox" id="box">
+    <child>
+      <object class="GtkLabel" id="first"/>
+      <packing><property name="pack_type">end</property></packing>
+    </child>
+    <child>
+      <object class="GtkLabel" id="second"/>
+      <packing><property name="pack_type">end</property></packing>
+    </child>
+    <child>
+      <object class="GtkLabel" id="start"/>
+    </child>
+  </object>
+</interface>`, "box.ui")
+	list := Migrate(root)
+	if len(list) != 1 || list[0].String() != "box.ui:2:3: children packed at the end of box are now last, add an expanding child" {
+		t.Error("end children without expanding child should be reported, not", list)
+	}
+	if ids := strings.Join(idsOf(root.Lookup("box")), " "); ids != "start second first" {
+		t.Error("end children should be last in reverse order, not", ids)
+	}
+}
+
+func idsOf(box *uixml.Node) (list []string) {
+	for _, c := range box.Elements("child") {
+		for _, obj := range c.Elements("object") {
+			list = append(list, obj.Attr("id"))
+		}
+	}
+	return list
+}
@@ -0,0 +1,294 @@
+// Command uiview previews gtk.Builder interface files, in any format loaded by
+// buildhelp: XML, JSON, YAML or uidsl.
+//
+// The window shows the first top-level widget, with a side panel listing the
+// ids and classes of all objects, and the builder errors. Selecting an id
+// highlights its widget. The preview is rebuilt when the file or an included
+// fragment is saved. Windows are previewed by their child.
+//
+// Usage:
+//
+//   uiview [-root id] [-broadway :5] [-screenshot preview.png] main.ui
+//
+// With -screenshot, the preview is saved as PNG once rendered and the command
+// exits, with the code 1 if the interface has errors.
+//
+// With -broadway, gtk4-broadwayd is started on the display and the window is
+// shown there: open http://localhost:8085 for :5. Combined with -screenshot,
+// this needs no X or Wayland display, like on CI machines.
+//
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gtkool4/grun"
+	"github.com/gtkool4/gtkelp/buildhelp"
+	"github.com/gtkool4/gtkelp/gtknew"
+
+	externglib "github.com/diamondburned/gotk4/pkg/core/glib"
+	"github.com/diamondburned/gotk4/pkg/gdk/v4"
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+)
+
+// Settings.
+var (
+	BroadwayDaemon  = "gtk4-broadwayd"       // Command started with -broadway.
+	BroadwayPort    = 8080                   // Port of the display :0, +1 by display.
+	BroadwayTimeout = 5 * time.Second        // Wait for the daemon.
+	ScreenshotDelay = 500 * time.Millisecond // Wait for the first frames.
+	HighlightClass  = "uiview-highlight"
+	HighlightCSS    = ".uiview-highlight { outline: 2px solid #e01b24; outline-offset: -2px; background-color: alpha(#e01b24, 0.2); }"
+)
+
+// Errors formating.
+var (
+	FmtErrRoot       = "%s is not a top-level widget or window" // Format: id
+	FmtErrScreenshot = "screenshot %s: %s"                      // Format: file, message
+	TxtErrBroadway   = "broadway daemon did not start"
+	TxtErrNoRoot     = "no top-level widget with an id"
+	TxtErrRender     = "nothing rendered"
+	TxtErrSave       = "can't write the PNG file"
+	TxtNoErrors      = "No errors"
+)
+
+func main() { os.Exit(run()) }
+
+func run() int {
+	rootID := flag.String("root", "", "id of the previewed widget (default: first top-level widget)")
+	display := flag.String("broadway", "", "start a broadway daemon on the display, like :5, and show the window there")
+	screenshot := flag.String("screenshot", "", "save the preview as PNG file and exit")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: uiview [flags] file")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		return 2
+	}
+
+	if *display != "" {
+		daemon, e := startBroadway(*display)
+		if e != nil {
+			fmt.Fprintln(os.Stderr, "uiview:", e)
+			return 1
+		}
+		defer daemon.Process.Kill()
+	}
+
+	file := flag.Arg(0)
+	v := &viewer{rootID: *rootID}
+	app := grun.NewLarge(grun.SetID("com.github.gtkool4.gtkelp.uiview"), grun.SetTitle("uiview: "+file), grun.SetFlagNonUnique())
+	return app.Run(
+		func() gtk.Widgetter { return v.widget(file) },
+		func(app *grun.App) {
+			if *screenshot == "" {
+				return
+			}
+			externglib.TimeoutAdd(uint(ScreenshotDelay/time.Millisecond), func() bool {
+				app.Exit(v.screenshot(app.Win, *screenshot))
+				return false
+			})
+		},
+	)
+}
+
+//
+//------------------------------------------------------------------[ VIEWER ]--
+
+// viewer previews the interface file, with the list of objects and errors.
+type viewer struct {
+	rootID    string
+	reloader  *buildhelp.Reloader
+	objects   *gtk.ScrolledWindow
+	errors    *gtk.Label
+	errs      grun.Errors   // Of the last reload.
+	ids       []string      // Of the object list rows.
+	highlight gtk.Widgetter // Highlighted widget.
+}
+
+// window is a previewed window, for its child.
+type window interface {
+	Present()
+	Child() gtk.Widgetter
+	SetChild(gtk.Widgetter)
+	Destroy()
+}
+
+// widget creates the window content.
+func (v *viewer) widget(file string) gtk.Widgetter {
+	css := gtk.NewCSSProvider()
+	css.LoadFromData([]byte(HighlightCSS))
+	gtk.StyleContextAddProviderForDisplay(gdk.DisplayGetDefault(), css, gtk.STYLE_PROVIDER_PRIORITY_APPLICATION)
+
+	v.objects = gtk.NewScrolledWindow()
+	v.objects.SetVExpand(true)
+	v.errors = gtk.NewLabel("")
+	v.errors.SetSelectable(true)
+	v.errors.SetWrap(true)
+	v.errors.SetXAlign(0)
+
+	v.reloader = buildhelp.NewReloader(file, v.build)
+	v.reloader.OnReload(v.refresh)
+	v.refresh(nil)
+
+	title := gtknew.LabelWithMarkup("<b>Errors</b>")
+	title.SetXAlign(0)
+	side := gtknew.VBox(6, v.objects, gtk.NewSeparator(gtk.OrientationHorizontal), title, v.errors)
+	side.SetSizeRequest(250, -1)
+	return gtknew.HPaned(gtknew.ScrolledWindow(&v.reloader.Box), side)
+}
+
+// build returns the previewed widget.
+func (v *viewer) build(b *buildhelp.BuildHelp) (gtk.Widgetter, grun.Errors) {
+	v.highlight = nil
+	ids := []string{v.rootID}
+	if v.rootID == "" {
+		ids = nil
+		for _, entry := range b.Audit() {
+			ids = append(ids, entry.ID)
+		}
+	}
+	for _, id := range ids {
+		obj := b.GetObject(id)
+		if obj == nil {
+			continue
+		}
+		switch w := obj.Cast().(type) {
+		case window:
+			child := w.Child()
+			w.SetChild(nil)
+			w.Destroy() // Top-level windows are kept by gtk until destroyed.
+			return child, nil
+
+		case gtk.Widgetter:
+			if w.Parent() == nil {
+				return w, nil
+			}
+		}
+	}
+	if v.rootID != "" {
+		return nil, grun.Errors{fmt.Errorf(FmtErrRoot, v.rootID)}
+	}
+	return nil, grun.Errors{errors.New(TxtErrNoRoot)}
+}
+
+// refresh updates the object list and the errors after a reload.
+func (v *viewer) refresh(errs grun.Errors) {
+	b := v.reloader.BuildHelp()
+	if !errs.IsError() && b != nil {
+		errs = b.Errors()
+	}
+	v.errs = errs
+	if errs.IsError() {
+		v.errors.SetText(errs.Error())
+		v.errors.AddCSSClass("error")
+	} else {
+		v.errors.SetText(TxtNoErrors)
+		v.errors.RemoveCSSClass("error")
+	}
+	if b == nil {
+		return
+	}
+
+	list := gtk.NewListBox()
+	v.ids = nil
+	for _, entry := range b.Audit() {
+		class := gtk.NewLabel(entry.Class)
+		class.AddCSSClass("dim-label")
+		class.SetHExpand(true)
+		class.SetXAlign(1)
+		row := gtknew.HBox(12, gtk.NewLabel(entry.ID), class)
+		row.SetTooltipText(entry.Pos.String())
+		list.Append(row)
+		v.ids = append(v.ids, entry.ID)
+	}
+	list.Connect("row-selected", func(_ *gtk.ListBox, row *gtk.ListBoxRow) {
+		if row != nil {
+			v.highlightID(v.ids[row.Index()])
+		}
+	})
+	v.objects.SetChild(list)
+}
+
+// highlightID highlights the widget of the id.
+func (v *viewer) highlightID(id string) {
+	if v.highlight != nil {
+		v.highlight.RemoveCSSClass(HighlightClass)
+		v.highlight = nil
+	}
+	obj := v.reloader.BuildHelp().GetObject(id)
+	if obj == nil {
+		return
+	}
+	if w, ok := obj.Cast().(gtk.Widgetter); ok {
+		w.AddCSSClass(HighlightClass)
+		v.highlight = w
+	}
+}
+
+// screenshot saves the preview as PNG, and returns the exit code: 1 if the
+// interface has errors.
+func (v *viewer) screenshot(win *gtk.ApplicationWindow, file string) int {
+	code := 0
+	if v.errs.IsError() {
+		fmt.Fprintln(os.Stderr, v.errs.Error())
+		code = 1
+	}
+	root := v.reloader.Root()
+	if root == nil {
+		return 1
+	}
+
+	snapshot := gtk.NewSnapshot()
+	gtk.NewWidgetPaintable(root).Snapshot(snapshot, float64(root.AllocatedWidth()), float64(root.AllocatedHeight()))
+	node := snapshot.ToNode()
+	if node == nil {
+		fmt.Fprintln(os.Stderr, "uiview:", fmt.Sprintf(FmtErrScreenshot, file, TxtErrRender))
+		return 1
+	}
+	if !win.Renderer().RenderTexture(node, nil).SaveToPng(file) {
+		fmt.Fprintln(os.Stderr, "uiview:", fmt.Sprintf(FmtErrScreenshot, file, TxtErrSave))
+		return 1
+	}
+	return code
+}
+
+//
+//----------------------------------------------------------------[ BROADWAY ]--
+
+// startBroadway starts the broadway daemon on the display, like :5, and sets
+// gtk to use it.
+func startBroadway(display string) (*exec.Cmd, error) {
+	n, e := strconv.Atoi(strings.TrimPrefix(display, ":"))
+	if e != nil {
+		return nil, e
+	}
+	cmd := exec.Command(BroadwayDaemon, display)
+	cmd.Stderr = os.Stderr
+	if e := cmd.Start(); e != nil {
+		return nil, e
+	}
+	os.Setenv("GDK_BACKEND", "broadway")
+	os.Setenv("BROADWAY_DISPLAY", display)
+
+	addr := net.JoinHostPort("localhost", strconv.Itoa(BroadwayPort+n))
+	for start := time.Now(); time.Since(start) < BroadwayTimeout; time.Sleep(50 * time.Millisecond) {
+		if conn, e := net.Dial("tcp", addr); e == nil {
+			conn.Close()
+			fmt.Fprintf(os.Stderr, "uiview: broadway display %s at http://%s\n", display, addr)
+			return cmd, nil
+		}
+	}
+	cmd.Process.Kill()
+	return nil, errors.New(TxtErrBroadway)
+}
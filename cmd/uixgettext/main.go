@@ -0,0 +1,115 @@
+// Command uixgettext extracts translatable strings from gtk.Builder files and
+// Go sources into a .pot template.
+//
+// Interface strings are the elements marked translatable="yes". Go strings are
+// the literal arguments of the calls listed in gettext.Keywords, like
+// buildhelp.Gettext or gtkext.Tr, and the -k keywords. Comments starting
+// with TRANSLATORS: before the call are kept.
+//
+// Usage:
+//
+//   uixgettext [-o messages.pot] [-k Tr:1 -k Pr:1c,2] file.ui file.go dir...
+//
+// Directories are walked for .ui and .go files, skipping testdata and hidden
+// directories.
+//
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gtkool4/gtkelp/gettext"
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+func main() {
+	output := flag.String("o", "", "output file (default: stdout)")
+	flag.Func("k", "additional keyword: name, name:id[,plural][,contextc]", func(spec string) error {
+		name, kw, e := gettext.ParseKeyword(spec)
+		if e == nil {
+			gettext.Keywords[name] = kw
+		}
+		return e
+	})
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: uixgettext [flags] file.ui file.go dir...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var tmpl gettext.Template
+	if e := Extract(&tmpl, flag.Args()...); e != nil {
+		fail(e)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, e := os.Create(*output)
+		if e != nil {
+			fail(e)
+		}
+		defer f.Close()
+		w = f
+	}
+	if _, e := tmpl.WriteTo(w); e != nil {
+		fail(e)
+	}
+}
+
+func fail(e error) {
+	fmt.Fprintln(os.Stderr, "uixgettext:", e)
+	os.Exit(1)
+}
+
+// Extract adds the strings of the files, and of the .ui and .go files in the
+// directories.
+func Extract(tmpl *gettext.Template, paths ...string) error {
+	for _, path := range paths {
+		e := filepath.WalkDir(path, func(file string, entry fs.DirEntry, e error) error {
+			switch {
+			case e != nil:
+				return e
+
+			case entry.IsDir():
+				if file != path && (entry.Name() == "testdata" || strings.HasPrefix(entry.Name(), ".")) {
+					return filepath.SkipDir
+				}
+				return nil
+
+			case file != path && !strings.HasSuffix(file, ".ui") && !strings.HasSuffix(file, ".go"):
+				return nil
+			}
+			return extractFile(tmpl, filepath.ToSlash(file))
+		})
+		if e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func extractFile(tmpl *gettext.Template, file string) error {
+	if strings.HasSuffix(file, ".go") {
+		src, e := os.ReadFile(file)
+		if e != nil {
+			return e
+		}
+		return tmpl.AddGo(file, src)
+	}
+	root, e := uixml.ParseFile(file)
+	if e != nil {
+		return e
+	}
+	tmpl.AddUI(root)
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gtkool4/gtkelp/gettext"
+)
+
+func TestExtract(t *testing.T) {
+	gettext.Keywords["Tr"] = gettext.Keyword{ID: 1}
+	defer delete(gettext.Keywords, "Tr")
+
+	var tmpl gettext.Template
+	if e := Extract(&tmpl, "testdata/main.go", "testdata/main.ui"); e != nil {
+		t.Fatal(e)
+	}
+	var found []string
+	for _, msg := range tmpl.Messages() {
+		found = append(found, msg.ID+" "+strings.Join(msg.Refs, " ")+" "+strings.Join(msg.Comments, " "))
+	}
+	expected := []string{
+		"%d file testdata/main.go:5 TRANSLATORS: Count of open files.",
+		"Editor testdata/main.go:6 testdata/main.ui:3 ",
+		"Custom testdata/main.go:8 ",
+		"Quit testdata/main.ui:6 Leaves the editor",
+	}
+	if strings.Join(found, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("extract:\nfound:\n%s\nexpected:\n%s", strings.Join(found, "\n"), strings.Join(expected, "\n"))
+	}
+
+	if e := Extract(&tmpl, "testdata/missing.ui"); e == nil {
+		t.Error("extract of a missing file should fail")
+	}
+}
@@ -0,0 +1,9 @@
+package main
+
+func status(n int) {
+	// TRANSLATORS: Count of open files.
+	buildhelp.NGettext("%d file", "%d files", n)
+	gtknew.Frame(gtkext.Tr("Editor"))
+	gtkext.Tr(title)
+	Tr("Custom")
+}
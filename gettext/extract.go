@@ -0,0 +1,309 @@
+package gettext
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+// Keyword defines the arguments of a translation call, numbered from 1. ID is
+// required, Plural and Context are 0 if unused.
+type Keyword struct {
+	ID, Plural, Context int
+}
+
+// Keywords lists the Go calls extracted, by function name or package.Function.
+// Methods match by name.
+var Keywords = map[string]Keyword{
+	"Gettext":   {ID: 1},
+	"PGettext":  {Context: 1, ID: 2},
+	"NGettext":  {ID: 1, Plural: 2},
+	"NPGettext": {Context: 1, ID: 2, Plural: 3},
+
+	"gtkext.Tr": {ID: 1},
+}
+
+// CommentTag marks Go comments given to translators, on the line before the
+// call or on the same line.
+var CommentTag = "TRANSLATORS:"
+
+// Header is the header entry written to templates.
+var Header = "Content-Type: text/plain; charset=UTF-8\nContent-Transfer-Encoding: 8bit\n"
+
+// Errors formating.
+var FmtErrKeyword = "keyword %q: want name or name:id[,plural][,contextc]" // Format: spec
+
+// ParseKeyword parses a keyword in xgettext format: name:1 for the first
+// argument, name:1,2 with a plural, name:1c,2 with a context.
+func ParseKeyword(spec string) (string, Keyword, error) {
+	fail := func() (string, Keyword, error) { return "", Keyword{}, fmt.Errorf(FmtErrKeyword, spec) }
+	parts := strings.SplitN(spec, ":", 2)
+	if parts[0] == "" {
+		return fail()
+	}
+	if len(parts) == 1 {
+		return parts[0], Keyword{ID: 1}, nil
+	}
+	var kw Keyword
+	for _, arg := range strings.Split(parts[1], ",") {
+		isContext := strings.HasSuffix(arg, "c")
+		index, e := strconv.Atoi(strings.TrimSuffix(arg, "c"))
+		switch {
+		case e != nil || index < 1:
+			return fail()
+		case isContext:
+			kw.Context = index
+		case kw.ID == 0:
+			kw.ID = index
+		case kw.Plural == 0:
+			kw.Plural = index
+		default:
+			return fail()
+		}
+	}
+	if kw.ID == 0 {
+		return fail()
+	}
+	return parts[0], kw, nil
+}
+
+//
+//----------------------------------------------------------------[ TEMPLATE ]--
+
+// Message is a translatable string found in sources.
+type Message struct {
+	Context  string
+	ID       string
+	Plural   string
+	Comments []string // For translators.
+	Refs     []string // Source positions: file:line.
+}
+
+// Template collects messages to write a .pot file. The zero value is ready to
+// use.
+type Template struct {
+	msgs  []*Message
+	index map[string]*Message
+}
+
+// Messages returns the messages in the order they were found.
+func (t *Template) Messages() []*Message { return t.msgs }
+
+// Add adds the message, or merges its comments and references with the same
+// message already found.
+func (t *Template) Add(msg Message) {
+	key := msg.Context + ctxSep + msg.ID
+	found, ok := t.index[key]
+	if !ok {
+		if t.index == nil {
+			t.index = map[string]*Message{}
+		}
+		found = &Message{Context: msg.Context, ID: msg.ID}
+		t.index[key] = found
+		t.msgs = append(t.msgs, found)
+	}
+	if found.Plural == "" {
+		found.Plural = msg.Plural
+	}
+	for _, comment := range msg.Comments {
+		if !contains(found.Comments, comment) {
+			found.Comments = append(found.Comments, comment)
+		}
+	}
+	for _, ref := range msg.Refs {
+		if !contains(found.Refs, ref) {
+			found.Refs = append(found.Refs, ref)
+		}
+	}
+}
+
+// AddUI adds the translatable strings of the interface: elements with a
+// translatable="yes" attribute, with optional context and comments attributes.
+func (t *Template) AddUI(root *uixml.Node) {
+	root.Walk(func(n, _ *uixml.Node) bool {
+		if IsTranslatable(n) && n.Text != "" {
+			msg := Message{Context: n.Attr("context"), ID: n.Text, Refs: []string{ref(n.Pos.File, n.Pos.Line)}}
+			if comment := n.Attr("comments"); comment != "" {
+				msg.Comments = []string{comment}
+			}
+			t.Add(msg)
+		}
+		return true
+	})
+}
+
+// IsTranslatable returns true if the element is marked translatable.
+func IsTranslatable(n *uixml.Node) bool {
+	switch strings.ToLower(n.Attr("translatable")) {
+	case "yes", "true", "1", "y", "t":
+		return true
+	}
+	return false
+}
+
+// AddGo adds the strings of the Go source calls listed in Keywords. Only
+// string literals are extracted.
+func (t *Template) AddGo(file string, src []byte) error {
+	fset := token.NewFileSet()
+	f, e := parser.ParseFile(fset, file, src, parser.ParseComments)
+	if e != nil {
+		return e
+	}
+	comments := map[int]string{} // By end line.
+	for _, group := range f.Comments {
+		text := strings.TrimSpace(group.Text())
+		if i := strings.Index(text, CommentTag); i >= 0 {
+			comments[fset.Position(group.End()).Line] = strings.TrimSpace(text[i:])
+		}
+	}
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		kw, ok := keyword(call.Fun)
+		if !ok {
+			return true
+		}
+		arg := func(index int) (string, bool) {
+			if index == 0 {
+				return "", true
+			}
+			if index > len(call.Args) {
+				return "", false
+			}
+			return literal(call.Args[index-1])
+		}
+		id, ok1 := arg(kw.ID)
+		plural, ok2 := arg(kw.Plural)
+		context, ok3 := arg(kw.Context)
+		if !ok1 || !ok2 || !ok3 || id == "" {
+			return true
+		}
+
+		line := fset.Position(call.Pos()).Line
+		msg := Message{Context: context, ID: id, Plural: plural, Refs: []string{ref(file, line)}}
+		for _, l := range []int{line, line - 1} {
+			if comment, ok := comments[l]; ok {
+				msg.Comments = []string{comment}
+				break
+			}
+		}
+		t.Add(msg)
+		return true
+	})
+	return nil
+}
+
+// keyword returns the keyword of the called function.
+func keyword(fun ast.Expr) (Keyword, bool) {
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		kw, ok := Keywords[fun.Name]
+		return kw, ok
+
+	case *ast.SelectorExpr:
+		if x, ok := fun.X.(*ast.Ident); ok {
+			if kw, ok := Keywords[x.Name+"."+fun.Sel.Name]; ok {
+				return kw, true
+			}
+		}
+		kw, ok := Keywords[fun.Sel.Name]
+		return kw, ok
+	}
+	return Keyword{}, false
+}
+
+// literal returns the value of a string literal, or a concatenation of literals.
+func literal(expr ast.Expr) (string, bool) {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		if expr.Kind != token.STRING {
+			return "", false
+		}
+		str, e := strconv.Unquote(expr.Value)
+		return str, e == nil
+
+	case *ast.ParenExpr:
+		return literal(expr.X)
+
+	case *ast.BinaryExpr:
+		if expr.Op != token.ADD {
+			return "", false
+		}
+		x, ok1 := literal(expr.X)
+		y, ok2 := literal(expr.Y)
+		return x + y, ok1 && ok2
+	}
+	return "", false
+}
+
+func ref(file string, line int) string { return file + ":" + strconv.Itoa(line) }
+
+//
+//-------------------------------------------------------------------[ WRITE ]--
+
+// WriteTo writes the template in .pot format.
+func (t *Template) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	writeString(&buf, "msgid", "")
+	writeString(&buf, "msgstr", Header)
+	for _, msg := range t.msgs {
+		buf.WriteString("\n")
+		for _, comment := range msg.Comments {
+			for _, line := range strings.Split(comment, "\n") {
+				buf.WriteString(strings.TrimSpace("#. "+line) + "\n")
+			}
+		}
+		if len(msg.Refs) > 0 {
+			buf.WriteString("#: " + strings.Join(msg.Refs, " ") + "\n")
+		}
+		if msg.Context != "" {
+			writeString(&buf, "msgctxt", msg.Context)
+		}
+		writeString(&buf, "msgid", msg.ID)
+		if msg.Plural != "" {
+			writeString(&buf, "msgid_plural", msg.Plural)
+			writeString(&buf, "msgstr[0]", "")
+			writeString(&buf, "msgstr[1]", "")
+		} else {
+			writeString(&buf, "msgstr", "")
+		}
+	}
+	n, e := w.Write(buf.Bytes())
+	return int64(n), e
+}
+
+// writeString writes a keyword and its quoted string, split after new lines.
+func writeString(buf *bytes.Buffer, keyword, str string) {
+	lines := strings.SplitAfter(str, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > 1 {
+		buf.WriteString(keyword + " \"\"\n")
+		keyword = ""
+	}
+	if len(lines) == 0 {
+		lines = []string{""}
+	}
+	for _, line := range lines {
+		if keyword != "" {
+			buf.WriteString(keyword + " ")
+		}
+		buf.WriteString(quote(line) + "\n")
+	}
+}
+
+// quote quotes the string with C escapes.
+func quote(str string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`).Replace(str) + `"`
+}
@@ -0,0 +1,230 @@
+// Package gettext translates strings with .po and .mo catalogs, and extracts
+// translatable strings from interface files and Go code into .pot templates.
+//
+// Catalogs are loaded from a fs.FS, like an embed.FS, without system locale
+// directories:
+//
+//   //go:embed locale
+//   var locale embed.FS
+//
+//   cat, e := gettext.Load(locale, "myapp") // locale/fr/LC_MESSAGES/myapp.mo
+//   cat.NGettext("%d file", "%d files", n)
+//
+// A nil *Catalog returns the strings untranslated.
+package gettext
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// Paths lists the catalog paths tried in the fs.FS for each language, with
+// {lang} and {domain} replaced.
+var Paths = []string{
+	"{lang}/LC_MESSAGES/{domain}.mo",
+	"{lang}/LC_MESSAGES/{domain}.po",
+	"{lang}/{domain}.mo",
+	"{lang}/{domain}.po",
+	"{lang}.mo",
+	"{lang}.po",
+}
+
+// Errors formating.
+var FmtErrNotFound = "gettext: no catalog for %s in %s: %w" // Format: domain, languages, ErrNotFound
+
+// ErrNotFound is returned, wrapped, by Load when no catalog matches the
+// languages. The strings are then untranslated.
+var ErrNotFound = fs.ErrNotExist
+
+// ctxSep separates the context from the message id in catalog keys.
+const ctxSep = "\x04"
+
+//
+//-----------------------------------------------------------------[ CATALOG ]--
+
+// Catalog holds the translations of a language.
+type Catalog struct {
+	Lang   string              // Language of the loaded file: fr_FR, fr...
+	msgs   map[string][]string // Translations by [context ctxSep] id, with plural forms.
+	plural func(n int) int     // Returns the plural form index.
+}
+
+func newCatalog() *Catalog {
+	return &Catalog{msgs: map[string][]string{}, plural: germanic}
+}
+
+// Load loads the catalog of the domain for the first language found, or the
+// user languages if none is given. Languages like fr_FR.UTF-8 also try fr_FR
+// and fr. See Paths.
+//
+// Returns an error wrapping ErrNotFound if no catalog matches.
+func Load(fsys fs.FS, domain string, langs ...string) (*Catalog, error) {
+	if len(langs) == 0 {
+		langs = Languages()
+	}
+	for _, lang := range langs {
+		for _, variant := range variants(lang) {
+			for _, pattern := range Paths {
+				name := strings.NewReplacer("{lang}", variant, "{domain}", domain).Replace(pattern)
+				data, e := fs.ReadFile(fsys, name)
+				if errors.Is(e, fs.ErrNotExist) {
+					continue
+				}
+				if e != nil {
+					return nil, e
+				}
+				cat, e := Parse(data, name)
+				if e != nil {
+					return nil, e
+				}
+				cat.Lang = variant
+				return cat, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf(FmtErrNotFound, domain, strings.Join(langs, ", "), ErrNotFound)
+}
+
+// Parse parses a .mo or .po catalog. The name is used for errors.
+func Parse(data []byte, name string) (*Catalog, error) {
+	if isMO(data) {
+		return ParseMO(data, name)
+	}
+	return ParsePO(data, name)
+}
+
+// Languages returns the user languages from the environment: LANGUAGE, LC_ALL,
+// LC_MESSAGES and LANG. The C and POSIX locales are dropped.
+func Languages() (list []string) {
+	locale := ""
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale = os.Getenv(key); locale != "" {
+			break
+		}
+	}
+	if locale == "" || locale == "C" || locale == "POSIX" {
+		return nil
+	}
+	for _, lang := range strings.Split(os.Getenv("LANGUAGE"), ":") {
+		if lang != "" {
+			list = append(list, lang)
+		}
+	}
+	return append(list, locale)
+}
+
+// variants returns the language and its shorter forms: fr_FR.UTF-8@euro, fr_FR, fr.
+func variants(lang string) []string {
+	list := []string{lang}
+	for _, sep := range []string{"@", ".", "_"} {
+		if i := strings.Index(lang, sep); i > 0 {
+			lang = lang[:i]
+			if list[len(list)-1] != lang {
+				list = append(list, lang)
+			}
+		}
+	}
+	return list
+}
+
+// Gettext returns the translation of the message.
+func (c *Catalog) Gettext(msgid string) string {
+	return c.lookup("", msgid, msgid, 1)
+}
+
+// PGettext returns the translation of the message in the context, used to
+// translate the same string differently, like "Open" the file or the state.
+func (c *Catalog) PGettext(context, msgid string) string {
+	return c.lookup(context, msgid, msgid, 1)
+}
+
+// NGettext returns the plural form of the translation for the count n.
+func (c *Catalog) NGettext(msgid, plural string, n int) string {
+	return c.lookup("", msgid, plural, n)
+}
+
+// NPGettext returns the plural form of the translation in the context for the
+// count n.
+func (c *Catalog) NPGettext(context, msgid, plural string, n int) string {
+	return c.lookup(context, msgid, plural, n)
+}
+
+// Len returns the number of translated messages.
+func (c *Catalog) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.msgs)
+}
+
+func (c *Catalog) lookup(context, msgid, plural string, n int) string {
+	if c == nil {
+		if germanic(n) == 0 {
+			return msgid
+		}
+		return plural
+	}
+	key := msgid
+	if context != "" {
+		key = context + ctxSep + msgid
+	}
+	forms := c.msgs[key]
+	if len(forms) == 0 {
+		return (*Catalog)(nil).lookup(context, msgid, plural, n)
+	}
+	if i := c.plural(n); i < len(forms) && forms[i] != "" {
+		return forms[i]
+	}
+	return forms[0]
+}
+
+// add sets the translation of the message, or parses the header.
+func (c *Catalog) add(context, msgid string, forms []string) error {
+	if msgid == "" && context == "" {
+		if len(forms) > 0 {
+			return c.header(forms[0])
+		}
+		return nil
+	}
+	for _, form := range forms {
+		if form != "" { // Untranslated entries are skipped.
+			key := msgid
+			if context != "" {
+				key = context + ctxSep + msgid
+			}
+			c.msgs[key] = forms
+			return nil
+		}
+	}
+	return nil
+}
+
+// header reads the plural rule of the catalog header.
+func (c *Catalog) header(header string) error {
+	for _, line := range strings.Split(header, "\n") {
+		kv := strings.SplitN(line, ":", 2)
+		if len(kv) == 2 && strings.EqualFold(strings.TrimSpace(kv[0]), "Plural-Forms") {
+			_, plural, e := parsePluralForms(kv[1])
+			if e != nil {
+				return e
+			}
+			c.plural = plural
+		}
+	}
+	return nil
+}
+
+//
+//-----------------------------------------------------------------[ HELPERS ]--
+
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,240 @@
+package gettext_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gtkool4/gtkelp/gettext"
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+var poFR = `# French translation.
+msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Plural-Forms: nplurals=2; plural=(n > 1);\n"
+
+#: main.ui:10
+msgid "Cut"
+msgstr "Couper"
+
+msgctxt "file"
+msgid "Open"
+msgstr "Ouvrir"
+
+msgctxt "state"
+msgid "Open"
+msgstr "Ouvert"
+
+msgid "%d file"
+msgid_plural "%d files"
+msgstr[0] "%d fichier"
+msgstr[1] "%d fichiers"
+
+msgid ""
+"Two\n"
+"lines"
+msgstr "Deux\n"
+"lignes"
+
+#, fuzzy
+msgid "Paste"
+msgstr "Copier"
+
+msgid "Quit"
+msgstr ""
+
+#~ msgid "Old"
+#~ msgstr "Vieux"
+`
+
+func TestCatalog(t *testing.T) {
+	fsys := fstest.MapFS{"locale/fr/LC_MESSAGES/app.po": {Data: []byte(poFR)}}
+	sub, _ := fsys.Sub("locale")
+	cat, e := gettext.Load(sub, "app", "de", "fr_FR.UTF-8")
+	if e != nil {
+		t.Fatal(e)
+	}
+	tests := map[string][2]string{ // Found, expected.
+		"lang":            {cat.Lang, "fr"},
+		"translated":      {cat.Gettext("Cut"), "Couper"},
+		"context file":    {cat.PGettext("file", "Open"), "Ouvrir"},
+		"context state":   {cat.PGettext("state", "Open"), "Ouvert"},
+		"no context":      {cat.Gettext("Open"), "Open"},
+		"plural one":      {cat.NGettext("%d file", "%d files", 1), "%d fichier"},
+		"plural zero":     {cat.NGettext("%d file", "%d files", 0), "%d fichier"},
+		"plural many":     {cat.NGettext("%d file", "%d files", 2), "%d fichiers"},
+		"multiline":       {cat.Gettext("Two\nlines"), "Deux\nlignes"},
+		"fuzzy":           {cat.Gettext("Paste"), "Paste"},
+		"untranslated":    {cat.Gettext("Quit"), "Quit"},
+		"obsolete":        {cat.Gettext("Old"), "Old"},
+		"unknown plural":  {cat.NGettext("%d dir", "%d dirs", 2), "%d dirs"},
+		"nil catalog":     {(*gettext.Catalog)(nil).NPGettext("x", "%d dir", "%d dirs", 1), "%d dir"},
+		"nil plural":      {(*gettext.Catalog)(nil).NGettext("%d dir", "%d dirs", 0), "%d dirs"},
+		"nil translation": {(*gettext.Catalog)(nil).Gettext("Cut"), "Cut"},
+	}
+	for name, pair := range tests {
+		testExpected(t, name, pair[0], pair[1])
+	}
+	if cat.Len() != 5 {
+		t.Error("catalog length", cat.Len())
+	}
+
+	_, e = gettext.Load(sub, "app", "de")
+	if !errors.Is(e, gettext.ErrNotFound) {
+		t.Error("missing catalog should wrap ErrNotFound:", e)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for data, expected := range map[string]string{
+		"msgid \"a\"\nmsgstr b":     "fr.po:2: string expected",
+		"msgid \"a\"\nmsgtxt \"b\"": "fr.po:2: unknown keyword msgtxt",
+		"\"a\"":                     "fr.po:1: string expected",
+		"msgid \"\"\nmsgstr \"Plural-Forms: plural=n;\"": `fr.po:2: plural forms " plural=n;": missing nplurals or plural`,
+	} {
+		_, e := gettext.ParsePO([]byte(data), "fr.po")
+		if e == nil || e.Error() != expected {
+			t.Errorf("parse %q: found %v, expected %s", data, e, expected)
+		}
+	}
+	if _, e := gettext.ParseMO([]byte{0xde, 0x12, 0x04, 0x95, 0, 0}, "fr.mo"); e == nil || e.Error() != "fr.mo: invalid .mo file" {
+		t.Error("bad .mo:", e)
+	}
+}
+
+func TestMO(t *testing.T) {
+	data := buildMO(
+		"", "Plural-Forms: nplurals=3; plural=n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2;\n",
+		"Cut", "Вырезать",
+		"state\x04Open", "Открыт",
+		"%d file\x00%d files", "%d файл\x00%d файла\x00%d файлов",
+	)
+	cat, e := gettext.Parse(data, "ru.mo")
+	if e != nil {
+		t.Fatal(e)
+	}
+	testExpected(t, "mo", cat.Gettext("Cut"), "Вырезать")
+	testExpected(t, "mo context", cat.PGettext("state", "Open"), "Открыт")
+	for n, expected := range map[int]string{1: "%d файл", 3: "%d файла", 5: "%d файлов", 11: "%d файлов", 21: "%d файл", 22: "%d файла"} {
+		testExpected(t, "mo plural", cat.NGettext("%d file", "%d files", n), expected)
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	root, e := uixml.ParseString(`<interface>
+  <object class="GtkButton">
+    <property name="label" translatable="yes" context="file" comments="Menu item">Open</property>
+    <property name="tooltip-text" translatable="yes">Say "hi"</property>
+    <property name="name">not translated</property>
+  </object>
+  <menu id="menu">
+    <item><attribute name="label" translatable="yes">Open</attribute></item>
+  </menu>
+</interface>`, "main.ui")
+	if e != nil {
+		t.Fatal(e)
+	}
+	var tmpl gettext.Template
+	tmpl.AddUI(root)
+	e = tmpl.AddGo("main.go", []byte(`package main
+
+func main() {
+	// TRANSLATORS: Shown in the status bar.
+	cat.NGettext("%d file", "%d files", n)
+	gtknew.Frame(gtkext.Tr("Options" + "\n"))
+	gtkext.Tr(title)
+	other.Frame("Not a keyword")
+	cat.PGettext("file", "Open") // TRANSLATORS: Same as the menu.
+}
+`))
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	var buf bytes.Buffer
+	tmpl.WriteTo(&buf)
+	testExpected(t, "template", buf.String(), `msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Content-Transfer-Encoding: 8bit\n"
+
+#. Menu item
+#. TRANSLATORS: Same as the menu.
+#: main.ui:3 main.go:9
+msgctxt "file"
+msgid "Open"
+msgstr ""
+
+#: main.ui:4
+msgid "Say \"hi\""
+msgstr ""
+
+#: main.ui:8
+msgid "Open"
+msgstr ""
+
+#. TRANSLATORS: Shown in the status bar.
+#: main.go:5
+msgid "%d file"
+msgid_plural "%d files"
+msgstr[0] ""
+msgstr[1] ""
+
+#: main.go:6
+msgid "Options\n"
+msgstr ""
+`)
+}
+
+func TestParseKeyword(t *testing.T) {
+	for spec, expected := range map[string]gettext.Keyword{
+		"T":         {ID: 1},
+		"T:2":       {ID: 2},
+		"N:1,2":     {ID: 1, Plural: 2},
+		"P:1c,2":    {Context: 1, ID: 2},
+		"NP:1c,2,3": {Context: 1, ID: 2, Plural: 3},
+	} {
+		_, kw, e := gettext.ParseKeyword(spec)
+		if e != nil || kw != expected {
+			t.Errorf("keyword %s: found %+v %v, expected %+v", spec, kw, e, expected)
+		}
+	}
+	for _, spec := range []string{"", "T:", "T:0", "T:1c", "T:1,2,3"} {
+		if _, _, e := gettext.ParseKeyword(spec); e == nil {
+			t.Errorf("keyword %q should fail", spec)
+		}
+	}
+}
+
+// buildMO encodes msgid, msgstr pairs as a little endian .mo file.
+func buildMO(pairs ...string) []byte {
+	count := len(pairs) / 2
+	var strs bytes.Buffer
+	origTable := make([]uint32, 0, count*2)
+	transTable := make([]uint32, 0, count*2)
+	base := 28 + count*16
+	for i := 0; i < len(pairs); i += 2 {
+		origTable = append(origTable, uint32(len(pairs[i])), uint32(base+strs.Len()))
+		strs.WriteString(pairs[i] + "\x00")
+		transTable = append(transTable, uint32(len(pairs[i+1])), uint32(base+strs.Len()))
+		strs.WriteString(pairs[i+1] + "\x00")
+	}
+	var buf bytes.Buffer
+	header := []uint32{0x950412de, 0, uint32(count), 28, uint32(28 + count*8), 0, 0}
+	for _, list := range [][]uint32{header, origTable, transTable} {
+		binary.Write(&buf, binary.LittleEndian, list)
+	}
+	buf.Write(strs.Bytes())
+	return buf.Bytes()
+}
+
+func testExpected(t *testing.T, name, found, expected string) {
+	t.Helper()
+	if found != expected {
+		t.Errorf("%s:\nfound    %q\nexpected %q", name, found, expected)
+	}
+}
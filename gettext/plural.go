@@ -0,0 +1,239 @@
+package gettext
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Errors formating.
+var FmtErrPlural = "plural forms %q: %s" // Format: header, message
+
+// germanic is the plural rule of untranslated strings: one, other.
+func germanic(n int) int {
+	if n == 1 {
+		return 0
+	}
+	return 1
+}
+
+// parsePluralForms parses a Plural-Forms header value, like
+// "nplurals=2; plural=(n != 1);".
+func parsePluralForms(header string) (nplurals int, plural func(int) int, e error) {
+	fail := func(msg string) (int, func(int) int, error) {
+		return 0, nil, fmt.Errorf(FmtErrPlural, header, msg)
+	}
+	var expr string
+	for _, field := range strings.Split(header, ";") {
+		kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch strings.TrimSpace(kv[0]) {
+		case "nplurals":
+			nplurals, e = strconv.Atoi(strings.TrimSpace(kv[1]))
+			if e != nil || nplurals < 1 {
+				return fail("bad nplurals")
+			}
+		case "plural":
+			expr = kv[1]
+		}
+	}
+	if nplurals == 0 || expr == "" {
+		return fail("missing nplurals or plural")
+	}
+
+	p := &pluralParser{src: expr}
+	eval, e := p.ternary()
+	if e == nil && p.next() != "" {
+		e = fmt.Errorf("unexpected %q", p.next())
+	}
+	if e != nil {
+		return fail(e.Error())
+	}
+	return nplurals, func(n int) int {
+		if i := eval(n); i >= 0 && i < nplurals {
+			return i
+		}
+		return 0
+	}, nil
+}
+
+//
+//------------------------------------------------------------------[ PARSER ]--
+
+// pluralParser parses the C expression of a plural rule, in the variable n.
+type pluralParser struct {
+	src string
+	pos int
+}
+
+type evalFunc func(n int) int
+
+// binaryOps lists binary operators by precedence, from lowest.
+var binaryOps = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<=", ">=", "<", ">"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// next returns the next token without consuming it.
+func (p *pluralParser) next() string {
+	for p.pos < len(p.src) && strings.ContainsRune(" \t\n", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	if p.pos == len(p.src) {
+		return ""
+	}
+	rest := p.src[p.pos:]
+	if i := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' }); i != 0 {
+		if i < 0 {
+			return rest
+		}
+		return rest[:i]
+	}
+	for _, op := range []string{"||", "&&", "==", "!=", "<=", ">="} {
+		if strings.HasPrefix(rest, op) {
+			return op
+		}
+	}
+	return rest[:1]
+}
+
+func (p *pluralParser) eat(token string) bool {
+	if p.next() != token || token == "" {
+		return false
+	}
+	p.pos += len(token)
+	return true
+}
+
+// ternary parses cond ? a : b, the lowest precedence.
+func (p *pluralParser) ternary() (evalFunc, error) {
+	cond, e := p.binary(0)
+	if e != nil || !p.eat("?") {
+		return cond, e
+	}
+	yes, e := p.ternary()
+	if e != nil {
+		return nil, e
+	}
+	if !p.eat(":") {
+		return nil, fmt.Errorf("missing : at %d", p.pos)
+	}
+	no, e := p.ternary()
+	if e != nil {
+		return nil, e
+	}
+	return func(n int) int {
+		if cond(n) != 0 {
+			return yes(n)
+		}
+		return no(n)
+	}, nil
+}
+
+// binary parses operators of the level and higher.
+func (p *pluralParser) binary(level int) (evalFunc, error) {
+	if level == len(binaryOps) {
+		return p.unary()
+	}
+	left, e := p.binary(level + 1)
+	for e == nil {
+		op := p.next()
+		if !contains(binaryOps[level], op) {
+			return left, nil
+		}
+		p.eat(op)
+		var right evalFunc
+		right, e = p.binary(level + 1)
+		left = binaryFunc(op, left, right)
+	}
+	return nil, e
+}
+
+// unary parses !x, (x), n and numbers.
+func (p *pluralParser) unary() (evalFunc, error) {
+	token := p.next()
+	switch {
+	case token == "!":
+		p.eat(token)
+		x, e := p.unary()
+		if e != nil {
+			return nil, e
+		}
+		return func(n int) int { return boolInt(x(n) == 0) }, nil
+
+	case token == "(":
+		p.eat(token)
+		x, e := p.ternary()
+		if e != nil {
+			return nil, e
+		}
+		if !p.eat(")") {
+			return nil, fmt.Errorf("missing ) at %d", p.pos)
+		}
+		return x, nil
+
+	case token == "n":
+		p.eat(token)
+		return func(n int) int { return n }, nil
+
+	case token != "" && token[0] >= '0' && token[0] <= '9':
+		p.eat(token)
+		value, e := strconv.Atoi(token)
+		return func(int) int { return value }, e
+	}
+	return nil, fmt.Errorf("unexpected %q at %d", token, p.pos)
+}
+
+func binaryFunc(op string, a, b evalFunc) evalFunc {
+	switch op {
+	case "||":
+		return func(n int) int { return boolInt(a(n) != 0 || b(n) != 0) }
+	case "&&":
+		return func(n int) int { return boolInt(a(n) != 0 && b(n) != 0) }
+	case "==":
+		return func(n int) int { return boolInt(a(n) == b(n)) }
+	case "!=":
+		return func(n int) int { return boolInt(a(n) != b(n)) }
+	case "<=":
+		return func(n int) int { return boolInt(a(n) <= b(n)) }
+	case ">=":
+		return func(n int) int { return boolInt(a(n) >= b(n)) }
+	case "<":
+		return func(n int) int { return boolInt(a(n) < b(n)) }
+	case ">":
+		return func(n int) int { return boolInt(a(n) > b(n)) }
+	case "+":
+		return func(n int) int { return a(n) + b(n) }
+	case "-":
+		return func(n int) int { return a(n) - b(n) }
+	case "*":
+		return func(n int) int { return a(n) * b(n) }
+	case "/":
+		return func(n int) int { return safeDiv(a(n), b(n), false) }
+	}
+	return func(n int) int { return safeDiv(a(n), b(n), true) }
+}
+
+// safeDiv divides or returns the modulo, or 0 when dividing by 0.
+func safeDiv(a, b int, mod bool) int {
+	switch {
+	case b == 0:
+		return 0
+	case mod:
+		return a % b
+	}
+	return a / b
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,184 @@
+package gettext
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Errors formating.
+var (
+	FmtErrPO    = "%s:%d: %s" // Format: file, line, message
+	FmtErrMO    = "%s: %s"    // Format: file, message
+	TxtErrMO    = "invalid .mo file"
+	TxtErrQuote = "string expected"
+)
+
+// moMagic starts .mo files, in the file byte order.
+const moMagic = 0x950412de
+
+//
+//----------------------------------------------------------------------[ PO ]--
+
+// ParsePO parses a .po catalog. The name is used for errors.
+//
+// Fuzzy and obsolete entries are skipped.
+func ParsePO(data []byte, name string) (*Catalog, error) {
+	cat := newCatalog()
+	var (
+		context, msgid string
+		forms          []string
+		fuzzy          bool
+		field          *string // Current string, for continuation lines.
+	)
+	// next ends the entry once its translations are read.
+	next := func() error {
+		if forms == nil {
+			return nil
+		}
+		var e error
+		if !fuzzy {
+			e = cat.add(context, msgid, forms)
+		}
+		context, msgid, forms, fuzzy, field = "", "", nil, false, nil
+		return e
+	}
+
+	scan := bufio.NewScanner(bytes.NewReader(data))
+	scan.Buffer(nil, len(data)+1)
+	line := 0
+	for scan.Scan() {
+		line++
+		text := strings.TrimSpace(scan.Text())
+		fail := func(msg string) (*Catalog, error) { return nil, fmt.Errorf(FmtErrPO, name, line, msg) }
+
+		switch {
+		case text == "":
+			continue
+
+		case strings.HasPrefix(text, "#"):
+			if e := next(); e != nil {
+				return fail(e.Error())
+			}
+			if strings.HasPrefix(text, "#,") && strings.Contains(text, "fuzzy") {
+				fuzzy = true
+			}
+			field = nil // Obsolete #~ entries are comments.
+			continue
+
+		case strings.HasPrefix(text, `"`):
+			if field == nil {
+				return fail(TxtErrQuote)
+			}
+			str, e := strconv.Unquote(text)
+			if e != nil {
+				return fail(e.Error())
+			}
+			*field += str
+			continue
+		}
+
+		keyword, value := text, ""
+		if i := strings.IndexAny(text, " \t"); i > 0 {
+			keyword, value = text[:i], strings.TrimSpace(text[i:])
+		}
+		str, e := strconv.Unquote(value)
+		if e != nil {
+			return fail(TxtErrQuote)
+		}
+
+		switch {
+		case keyword == "msgctxt", keyword == "msgid":
+			if e := next(); e != nil {
+				return fail(e.Error())
+			}
+			if keyword == "msgctxt" {
+				context, field = str, &context
+			} else {
+				msgid, field = str, &msgid
+			}
+
+		case keyword == "msgid_plural":
+			field = new(string) // Only the translations are kept.
+
+		case keyword == "msgstr", strings.HasPrefix(keyword, "msgstr["):
+			index := 0
+			if keyword != "msgstr" {
+				index, e = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(keyword, "msgstr["), "]"))
+				if e != nil || index > 100 {
+					return fail("bad plural index " + keyword)
+				}
+			}
+			for len(forms) <= index {
+				forms = append(forms, "")
+			}
+			forms[index] = str
+			field = &forms[index]
+
+		default:
+			return fail("unknown keyword " + keyword)
+		}
+	}
+	if e := next(); e != nil {
+		return nil, fmt.Errorf(FmtErrPO, name, line, e)
+	}
+	return cat, scan.Err()
+}
+
+//
+//----------------------------------------------------------------------[ MO ]--
+
+// isMO returns true if the data starts with the .mo magic number.
+func isMO(data []byte) bool {
+	return len(data) >= 4 && (binary.LittleEndian.Uint32(data) == moMagic || binary.BigEndian.Uint32(data) == moMagic)
+}
+
+// ParseMO parses a compiled .mo catalog. The name is used for errors.
+func ParseMO(data []byte, name string) (*Catalog, error) {
+	fail := func(msg string) (*Catalog, error) { return nil, fmt.Errorf(FmtErrMO, name, msg) }
+	if !isMO(data) || len(data) < 20 {
+		return fail(TxtErrMO)
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if order.Uint32(data) != moMagic {
+		order = binary.BigEndian
+	}
+	word := func(offset uint32) (uint32, bool) {
+		if uint64(offset)+4 > uint64(len(data)) {
+			return 0, false
+		}
+		return order.Uint32(data[offset:]), true
+	}
+	str := func(table, i uint32) (string, bool) {
+		length, ok1 := word(table + i*8)
+		offset, ok2 := word(table + i*8 + 4)
+		if !ok1 || !ok2 || uint64(offset)+uint64(length) > uint64(len(data)) {
+			return "", false
+		}
+		return string(data[offset : offset+length]), true
+	}
+
+	count, _ := word(8)
+	origTable, _ := word(12)
+	transTable, _ := word(16)
+	cat := newCatalog()
+	for i := uint32(0); i < count; i++ {
+		orig, ok1 := str(origTable, i)
+		trans, ok2 := str(transTable, i)
+		if !ok1 || !ok2 {
+			return fail(TxtErrMO)
+		}
+		context := ""
+		if j := strings.Index(orig, ctxSep); j >= 0 {
+			context, orig = orig[:j], orig[j+1:]
+		}
+		msgid := strings.SplitN(orig, "\x00", 2)[0]
+		if e := cat.add(context, msgid, strings.Split(trans, "\x00")); e != nil {
+			return fail(e.Error())
+		}
+	}
+	return cat, nil
+}
@@ -0,0 +1,335 @@
+// Package gir reads GObject introspection files, to check classes, properties,
+// signals and enums offline.
+//
+// Types are indexed by their GType name, like GtkButton, as used in gtk.Builder
+// interface files.
+package gir
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Dir is the default directory of GIR files.
+var Dir = "/usr/share/gir-1.0"
+
+// Errors formating.
+var FmtErrLoad = "gir %s: %w" // Format: file, error
+
+//
+//------------------------------------------------------------------[ TYPES ]--
+
+// Repository lists the types of loaded namespaces.
+type Repository struct {
+	Classes map[string]*Class // Classes and interfaces by GType name.
+	Enums   map[string]*Enum  // Enumerations and bitfields by GType name.
+}
+
+// Class defines a class or an interface.
+type Class struct {
+	Name       string // GType name: GtkButton.
+	Parent     string // GType name, empty for interfaces and root classes.
+	Interfaces []string
+	Properties map[string]*Property // By name, with dashes.
+	Signals    map[string]bool
+	Abstract   bool
+	Interface  bool
+}
+
+// Property defines a class property.
+type Property struct {
+	Name     string
+	Type     string // GType name for objects and enums, or GIR basic type: utf8, gboolean...
+	Writable bool
+}
+
+// Enum defines an enumeration or a bitfield.
+type Enum struct {
+	Name    string
+	Flags   bool
+	Members []string // Nicks and C identifiers.
+}
+
+// Valid returns true if the value is a member, an integer, or a list of members
+// separated by | for flags.
+func (en *Enum) Valid(value string) bool {
+	values := []string{value}
+	if en.Flags {
+		values = strings.Split(value, "|")
+	}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if _, e := strconv.ParseInt(v, 0, 64); e == nil {
+			continue
+		}
+		if !en.member(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (en *Enum) member(value string) bool {
+	for _, m := range en.Members {
+		if strings.EqualFold(m, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Class returns the class or interface, or nil if unknown.
+func (r *Repository) Class(name string) *Class { return r.Classes[name] }
+
+// Property returns the property of the class, its parents or interfaces.
+func (r *Repository) Property(class, name string) *Property {
+	name = strings.ReplaceAll(name, "_", "-")
+	var found *Property
+	r.walk(class, func(c *Class) bool {
+		found = c.Properties[name]
+		return found == nil
+	})
+	return found
+}
+
+// Signal returns true if the class, its parents or interfaces define the
+// signal. The detail of notify::label is ignored.
+func (r *Repository) Signal(class, name string) bool {
+	if i := strings.Index(name, "::"); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.ReplaceAll(name, "_", "-")
+	found := false
+	r.walk(class, func(c *Class) bool {
+		found = c.Signals[name]
+		return !found
+	})
+	return found
+}
+
+// IsA returns true if the class is or inherits the ancestor class or interface.
+func (r *Repository) IsA(class, ancestor string) bool {
+	found := false
+	r.walk(class, func(c *Class) bool {
+		found = c.Name == ancestor
+		return !found
+	})
+	return found
+}
+
+// walk calls the function for the class, its parents and interfaces until it
+// returns false.
+func (r *Repository) walk(name string, call func(*Class) bool) bool {
+	for c := r.Classes[name]; c != nil; c = r.Classes[c.Parent] {
+		if !call(c) {
+			return false
+		}
+		for _, iface := range c.Interfaces {
+			if !r.walk(iface, call) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//
+//-------------------------------------------------------------------[ LOAD ]--
+
+// Load reads the namespace files, like Gtk-4.0, and their includes from the
+// directory.
+func Load(dir string, namespaces ...string) (*Repository, error) {
+	l := &loader{dir: dir, loaded: map[string]bool{}, names: map[string]string{}}
+	for _, ns := range namespaces {
+		if e := l.load(ns); e != nil {
+			return nil, e
+		}
+	}
+	return l.resolve(), nil
+}
+
+// Parse reads GIR files, without includes.
+func Parse(files ...string) (*Repository, error) {
+	l := &loader{loaded: map[string]bool{}, names: map[string]string{}}
+	for _, file := range files {
+		if _, e := l.parse(file); e != nil {
+			return nil, e
+		}
+	}
+	return l.resolve(), nil
+}
+
+type loader struct {
+	dir    string
+	loaded map[string]bool
+	names  map[string]string // GType names by qualified GIR name: Gtk.Button.
+	spaces []*namespace
+}
+
+func (l *loader) load(ns string) error {
+	if l.loaded[ns] {
+		return nil
+	}
+	l.loaded[ns] = true
+	includes, e := l.parse(filepath.Join(l.dir, ns+".gir"))
+	if e != nil {
+		return e
+	}
+	for _, inc := range includes {
+		if e := l.load(inc.Name + "-" + inc.Version); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// parse reads the file and returns its includes.
+func (l *loader) parse(file string) ([]include, error) {
+	f, e := os.Open(file)
+	if e != nil {
+		return nil, e
+	}
+	defer f.Close()
+
+	var repo repository
+	if e := xml.NewDecoder(f).Decode(&repo); e != nil {
+		return nil, fmt.Errorf(FmtErrLoad, file, e)
+	}
+	for _, ns := range repo.Namespaces {
+		for _, list := range [][]class{ns.Classes, ns.Interfaces} {
+			for _, c := range list {
+				l.names[ns.Name+"."+c.Name] = c.TypeName
+			}
+		}
+		for _, list := range [][]enum{ns.Enums, ns.Bitfields} {
+			for _, en := range list {
+				l.names[ns.Name+"."+en.Name] = en.TypeName
+			}
+		}
+		l.spaces = append(l.spaces, ns)
+	}
+	return repo.Includes, nil
+}
+
+// resolve indexes the types by GType name.
+func (l *loader) resolve() *Repository {
+	r := &Repository{Classes: map[string]*Class{}, Enums: map[string]*Enum{}}
+	for _, ns := range l.spaces {
+		typeName := func(name string) string {
+			if !strings.Contains(name, ".") {
+				name = ns.Name + "." + name
+			}
+			return l.names[name]
+		}
+		addClass := func(c class, iface bool) {
+			if c.TypeName == "" {
+				return
+			}
+			cl := &Class{
+				Name:       c.TypeName,
+				Parent:     typeName(c.Parent),
+				Properties: map[string]*Property{},
+				Signals:    map[string]bool{},
+				Abstract:   c.Abstract == "1",
+				Interface:  iface,
+			}
+			for _, impl := range c.Implements {
+				cl.Interfaces = append(cl.Interfaces, typeName(impl.Name))
+			}
+			for _, p := range c.Properties {
+				typ := p.Type.Name
+				if name := typeName(typ); name != "" {
+					typ = name
+				}
+				cl.Properties[p.Name] = &Property{Name: p.Name, Type: typ, Writable: p.Writable == "1"}
+			}
+			for _, s := range c.Signals {
+				cl.Signals[s.Name] = true
+			}
+			r.Classes[cl.Name] = cl
+		}
+		for _, c := range ns.Classes {
+			addClass(c, false)
+		}
+		for _, c := range ns.Interfaces {
+			addClass(c, true)
+		}
+
+		addEnum := func(en enum, flags bool) {
+			if en.TypeName == "" {
+				return
+			}
+			e := &Enum{Name: en.TypeName, Flags: flags}
+			for _, m := range en.Members {
+				for _, name := range []string{m.Nick, m.Name, strings.ReplaceAll(m.Name, "_", "-"), m.Identifier} {
+					if name != "" {
+						e.Members = append(e.Members, name)
+					}
+				}
+			}
+			r.Enums[e.Name] = e
+		}
+		for _, en := range ns.Enums {
+			addEnum(en, false)
+		}
+		for _, en := range ns.Bitfields {
+			addEnum(en, true)
+		}
+	}
+	return r
+}
+
+//
+//---------------------------------------------------------------[ XML MODEL ]--
+
+type repository struct {
+	Includes   []include    `xml:"include"`
+	Namespaces []*namespace `xml:"namespace"`
+}
+
+type include struct {
+	Name    string `xml:"name,attr"`
+	Version string `xml:"version,attr"`
+}
+
+type namespace struct {
+	Name       string  `xml:"name,attr"`
+	Classes    []class `xml:"class"`
+	Interfaces []class `xml:"interface"`
+	Enums      []enum  `xml:"enumeration"`
+	Bitfields  []enum  `xml:"bitfield"`
+}
+
+type class struct {
+	Name       string `xml:"name,attr"`
+	TypeName   string `xml:"http://www.gtk.org/introspection/glib/1.0 type-name,attr"`
+	Parent     string `xml:"parent,attr"`
+	Abstract   string `xml:"abstract,attr"`
+	Implements []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"implements"`
+	Properties []struct {
+		Name     string `xml:"name,attr"`
+		Writable string `xml:"writable,attr"`
+		Type     struct {
+			Name string `xml:"name,attr"`
+		} `xml:"type"`
+	} `xml:"property"`
+	Signals []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"http://www.gtk.org/introspection/glib/1.0 signal"`
+}
+
+type enum struct {
+	Name     string `xml:"name,attr"`
+	TypeName string `xml:"http://www.gtk.org/introspection/glib/1.0 type-name,attr"`
+	Members  []struct {
+		Name       string `xml:"name,attr"`
+		Nick       string `xml:"http://www.gtk.org/introspection/glib/1.0 nick,attr"`
+		Identifier string `xml:"http://www.gtk.org/introspection/c/1.0 identifier,attr"`
+	} `xml:"member"`
+}
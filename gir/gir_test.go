@@ -0,0 +1,51 @@
+package gir_test
+
+import (
+	"testing"
+
+	"github.com/gtkool4/gtkelp/gir"
+)
+
+func TestLoad(t *testing.T) {
+	repo, e := gir.Load("testdata", "Gtk-4.0")
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	tests := map[string]bool{
+		"class GtkButton":         repo.Class("GtkButton") != nil,
+		"class GObject (include)": repo.Class("GObject") != nil,
+		"no class GtkNope":        repo.Class("GtkNope") == nil,
+		"parent":                  repo.Class("GtkWidget").Parent == "GInitiallyUnowned",
+		"is a GObject":            repo.IsA("GtkButton", "GObject"),
+		"is an interface":         repo.IsA("GtkBox", "GtkOrientable"),
+		"not a GtkBox":            !repo.IsA("GtkButton", "GtkBox"),
+		"property":                repo.Property("GtkButton", "icon-name") != nil,
+		"property underscore":     repo.Property("GtkButton", "icon_name") != nil,
+		"property inherited":      repo.Property("GtkButton", "tooltip-text") != nil,
+		"property interface":      repo.Property("GtkBox", "orientation").Type == "GtkOrientation",
+		"property object":         repo.Property("GtkLabel", "mnemonic-widget").Type == "GtkWidget",
+		"property basic":          repo.Property("GtkBox", "spacing").Type == "gint",
+		"no property":             repo.Property("GtkBox", "label") == nil,
+		"signal":                  repo.Signal("GtkButton", "clicked"),
+		"signal inherited":        repo.Signal("GtkButton", "notify::label"),
+		"no signal":               !repo.Signal("GtkBox", "clicked"),
+		"enum nick":               repo.Enums["GtkOrientation"].Valid("vertical"),
+		"enum identifier":         repo.Enums["GtkOrientation"].Valid("GTK_ORIENTATION_VERTICAL"),
+		"enum number":             repo.Enums["GtkOrientation"].Valid("1"),
+		"enum invalid":            !repo.Enums["GtkOrientation"].Valid("diagonal"),
+		"flags":                   repo.Enums["GtkStateFlags"].Valid("active | prelight"),
+		"flags invalid":           !repo.Enums["GtkStateFlags"].Valid("active|hover"),
+	}
+	for name, ok := range tests {
+		if !ok {
+			t.Error("gir check failed:", name)
+		}
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	if _, e := gir.Load("testdata", "Adw-1"); e == nil {
+		t.Error("load of a missing namespace should fail")
+	}
+}
@@ -1,10 +1,11 @@
 module github.com/gtkool4/gtkelp
 
-go 1.17
+go 1.18
 
 require (
 	github.com/diamondburned/gotk4/pkg v0.0.0-20210919215506-2625db339437
 	github.com/gtkool4/grun v0.0.0-20210920192350-bbfdfe66023b
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -1,4 +1,4 @@
-// Package gtkest defines a widget maker to run gtk tests.
+// Package gtkest defines a widget maker and helpers to run gtk tests.
 package gtkest
 
 import (
@@ -6,12 +6,25 @@ import (
 	"testing"
 	"time"
 
+	"github.com/diamondburned/gotk4/pkg/glib/v2"
 	"github.com/diamondburned/gotk4/pkg/gtk/v4"
 
 	"github.com/gtkool4/grun"
 	"github.com/gtkool4/gtkelp/gtknew"
 )
 
+// Settings.
+var (
+	IterateDelay = 5 * time.Millisecond // Pause of main loop waits with nothing to do.
+
+	// Update rewrites golden files with the test results instead of comparing.
+	// Wire it to a flag of the test package:
+	//
+	//   flag.BoolVar(&gtkest.Update, "update", false, "update golden files")
+	//
+	Update = false
+)
+
 // Maker defines a widget maker to run gtk tests.
 type Maker struct {
 	newW    func() gtk.Widgetter
@@ -58,3 +71,21 @@ func (m *Maker) Exit(exitCode int) func(*testing.T, gtk.Widgetter) {
 func (m *Maker) ExitAfter(d time.Duration, exitCode int) func() {
 	return func() { go time.AfterFunc(d, func() { m.app.Exit(exitCode) }) }
 }
+
+//
+//---------------------------------------------------------------[ MAIN LOOP ]--
+
+// iterate runs the default main context until the condition is true or the
+// timeout expires. Returns the last condition result.
+func iterate(timeout time.Duration, cond func() bool) bool {
+	ctx := glib.MainContextDefault()
+	for end := time.Now().Add(timeout); !cond(); {
+		if time.Now().After(end) {
+			return false
+		}
+		if !ctx.Iteration(false) {
+			time.Sleep(IterateDelay)
+		}
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+package gtkest
+
+// #include <gtk/gtk.h>
+//
+// // gtkest_render renders the widget with the cairo renderer, which needs no
+// // GPU or display surface. Returns NULL if nothing was drawn.
+// static GdkTexture *gtkest_render(GObject *w, int width, int height) {
+// 	GdkPaintable *paintable = gtk_widget_paintable_new(GTK_WIDGET(w));
+// 	GtkSnapshot *snapshot = gtk_snapshot_new();
+// 	gdk_paintable_snapshot(paintable, GDK_SNAPSHOT(snapshot), width, height);
+// 	GskRenderNode *node = gtk_snapshot_free_to_node(snapshot);
+// 	g_object_unref(paintable);
+// 	if (!node) {
+// 		return NULL;
+// 	}
+//
+// 	GskRenderer *renderer = gsk_cairo_renderer_new();
+// 	GdkTexture *texture = NULL;
+// 	if (gsk_renderer_realize(renderer, NULL, NULL)) {
+// 		graphene_rect_t viewport = GRAPHENE_RECT_INIT(0, 0, width, height);
+// 		texture = gsk_renderer_render_texture(renderer, node, &viewport);
+// 		gsk_renderer_unrealize(renderer);
+// 	}
+// 	g_object_unref(renderer);
+// 	gsk_render_node_unref(node);
+// 	return texture;
+// }
+//
+// static void gtkest_download(GdkTexture *texture, guchar *data, gsize stride) {
+// 	gdk_texture_download(texture, data, stride);
+// 	g_object_unref(texture);
+// }
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+)
+
+// Image settings.
+var (
+	RenderTimeout = time.Second // Wait for the widget allocation.
+
+	DefaultImageMatch = ImageMatch{Dir: "testdata", Tolerance: 2, MaxDiff: 0.001}
+
+	DiffColor = color.NRGBA{R: 255, A: 255} // Different pixels in diff images.
+)
+
+// Errors formating.
+var (
+	FmtErrImage      = "gtkest: image %s: %s"                                                // Format: file, message
+	FmtErrImageDiff  = "%.3f%% pixels differ, max %.3f%%, see %s.{actual,expected,diff}.png" // Format: ratio, max ratio, base file
+	FmtErrImageSize  = "size is %dx%d, want %dx%d"                                           // Format: width, height, golden width, golden height
+	TxtErrAllocated  = "widget is not allocated, show it in a window"
+	TxtErrRendered   = "nothing rendered"
+	TxtErrImageRerun = "set gtkest.Update to create it"
+)
+
+//
+//-------------------------------------------------------------------[ MATCH ]--
+
+// ImageMatch compares widgets rendering to golden PNG files.
+type ImageMatch struct {
+	Dir       string  // Golden files directory.
+	Tolerance uint8   // Max difference of a color channel for equal pixels.
+	MaxDiff   float64 // Max ratio of different pixels, from 0 to 1.
+}
+
+// MatchImage compares the widget rendering to the golden file name.png, with
+// DefaultImageMatch.
+func MatchImage(t testing.TB, w gtk.Widgetter, name string) {
+	t.Helper()
+	DefaultImageMatch.Match(t, w, name)
+}
+
+// Match compares the widget rendering to the golden file name.png. If Update
+// is set, the golden file is written instead.
+//
+// On failure, the actual, expected and diff images are written next to the
+// golden file, as name.actual.png, name.expected.png and name.diff.png. They
+// are removed when the test passes.
+func (m ImageMatch) Match(t testing.TB, w gtk.Widgetter, name string) {
+	t.Helper()
+	file := filepath.Join(m.Dir, name+".png")
+	base := filepath.Join(m.Dir, name)
+	have, e := RenderImage(w)
+	if e != nil {
+		t.Errorf(FmtErrImage, file, e)
+		return
+	}
+	if Update {
+		if e := writePNG(file, have); e != nil {
+			t.Errorf(FmtErrImage, file, e)
+		}
+		return
+	}
+
+	want, e := readPNG(file)
+	if e != nil {
+		t.Errorf(FmtErrImage, file, fmt.Sprintf("%s, %s", e, TxtErrImageRerun))
+		return
+	}
+	ratio, diff := DiffImages(have, want, m.Tolerance)
+	if !have.Bounds().Size().Eq(want.Bounds().Size()) {
+		size, wantSize := have.Bounds().Size(), want.Bounds().Size()
+		t.Errorf(FmtErrImage, file, fmt.Sprintf(FmtErrImageSize, size.X, size.Y, wantSize.X, wantSize.Y))
+	} else if ratio > m.MaxDiff {
+		t.Errorf(FmtErrImage, file, fmt.Sprintf(FmtErrImageDiff, ratio*100, m.MaxDiff*100, base))
+	} else {
+		for _, suffix := range []string{".actual.png", ".expected.png", ".diff.png"} {
+			os.Remove(base + suffix)
+		}
+		return
+	}
+
+	for suffix, img := range map[string]image.Image{".actual.png": have, ".expected.png": want, ".diff.png": diff} {
+		if e := writePNG(base+suffix, img); e != nil {
+			t.Errorf(FmtErrImage, base+suffix, e)
+		}
+	}
+}
+
+//
+//------------------------------------------------------------------[ RENDER ]--
+
+// RenderImage renders the widget offscreen at its allocated size, with the
+// cairo renderer. The widget must be shown in a window: the main loop is run
+// up to RenderTimeout for its allocation.
+func RenderImage(w gtk.Widgetter) (*image.NRGBA, error) {
+	if !iterate(RenderTimeout, func() bool { return w.AllocatedWidth() > 0 && w.AllocatedHeight() > 0 }) {
+		return nil, errors.New(TxtErrAllocated)
+	}
+	width, height := w.AllocatedWidth(), w.AllocatedHeight()
+	texture := C.gtkest_render(gobject(w), C.int(width), C.int(height))
+	if texture == nil {
+		return nil, errors.New(TxtErrRendered)
+	}
+	// Texture pixels are cairo ARGB32: premultiplied, native endian words.
+	pixels := make([]uint32, width*height)
+	C.gtkest_download(texture, (*C.guchar)(unsafe.Pointer(&pixels[0])), C.gsize(width*4))
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i, argb := range pixels {
+		c := color.RGBA{R: uint8(argb >> 16), G: uint8(argb >> 8), B: uint8(argb), A: uint8(argb >> 24)}
+		n := color.NRGBAModel.Convert(c).(color.NRGBA)
+		copy(img.Pix[4*i:4*i+4], []byte{n.R, n.G, n.B, n.A})
+	}
+	return img, nil
+}
+
+// DiffImages compares the images pixel by pixel: pixels differ when a color
+// channel differs by more than the tolerance. Pixels out of one image differ.
+//
+// Returns the ratio of different pixels, from 0 to 1, and an image with the
+// different pixels in DiffColor over the faded wanted image.
+func DiffImages(have, want image.Image, tolerance uint8) (float64, *image.NRGBA) {
+	bounds := have.Bounds().Union(want.Bounds())
+	diff := image.NewNRGBA(bounds)
+	count := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			p := image.Pt(x, y)
+			h := color.NRGBAModel.Convert(have.At(x, y)).(color.NRGBA)
+			w := color.NRGBAModel.Convert(want.At(x, y)).(color.NRGBA)
+			if !p.In(have.Bounds()) || !p.In(want.Bounds()) ||
+				differ(h.R, w.R, tolerance) || differ(h.G, w.G, tolerance) ||
+				differ(h.B, w.B, tolerance) || differ(h.A, w.A, tolerance) {
+				count++
+				diff.SetNRGBA(x, y, DiffColor)
+				continue
+			}
+			diff.SetNRGBA(x, y, color.NRGBA{R: w.R, G: w.G, B: w.B, A: w.A / 4})
+		}
+	}
+	if bounds.Empty() {
+		return 0, diff
+	}
+	return float64(count) / float64(bounds.Dx()*bounds.Dy()), diff
+}
+
+func differ(a, b, tolerance uint8) bool {
+	if a > b {
+		return a-b > tolerance
+	}
+	return b-a > tolerance
+}
+
+func readPNG(file string) (image.Image, error) {
+	f, e := os.Open(file)
+	if e != nil {
+		return nil, e
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+func writePNG(file string, img image.Image) error {
+	if e := os.MkdirAll(filepath.Dir(file), 0o755); e != nil {
+		return e
+	}
+	f, e := os.Create(file)
+	if e != nil {
+		return e
+	}
+	if e := png.Encode(f, img); e != nil {
+		f.Close()
+		return e
+	}
+	return f.Close()
+}
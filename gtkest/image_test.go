@@ -0,0 +1,104 @@
+package gtkest_test
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/gotk4/pkg/cairo"
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+
+	"github.com/gtkool4/gtkelp/gtkest"
+)
+
+func TestDiffImages(t *testing.T) {
+	want := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	have := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	for i := 0; i < 3; i++ {
+		have.SetNRGBA(i, i, color.NRGBA{R: 10})
+	}
+	for _, test := range []struct {
+		have      image.Image
+		tolerance uint8
+		ratio     float64
+	}{
+		{have, 2, 0.03},
+		{have, 10, 0},
+		{image.NewNRGBA(image.Rect(0, 0, 10, 5)), 0, 0.5},
+	} {
+		ratio, diff := gtkest.DiffImages(test.have, want, test.tolerance)
+		if ratio != test.ratio {
+			t.Errorf("diff ratio should be %v but we have %v", test.ratio, ratio)
+		}
+		if (diff.NRGBAAt(0, 0) == gtkest.DiffColor) != (test.ratio == 0.03) {
+			t.Errorf("diff image should show different pixels in DiffColor, have %v", diff.NRGBAAt(0, 0))
+		}
+	}
+}
+
+func TestMatchImage(t *testing.T) {
+	imageMaker.Run(t,
+		func(t *testing.T, w gtk.Widgetter) {
+			area := gtkest.Get[*gtk.DrawingArea](t, w)
+			gtkest.MatchImage(t, area, "drawing")
+
+			if gtkest.Update {
+				return
+			}
+
+			// Mismatch with a temporary golden file.
+			match := gtkest.DefaultImageMatch
+			match.Dir = t.TempDir()
+			blue := &fakeT{TB: t}
+			area.SetDrawFunc(drawColors(0, 0, 1))
+			match.Match(blue, area, "drawing") // No golden file.
+			golden, _ := os.ReadFile(filepath.Join("testdata", "drawing.png"))
+			os.WriteFile(filepath.Join(match.Dir, "drawing.png"), golden, 0o644)
+			match.Match(blue, area, "drawing")
+			if len(blue.errors) != 2 ||
+				!strings.HasSuffix(blue.errors[0], gtkest.TxtErrImageRerun) ||
+				!strings.Contains(blue.errors[1], "50.000% pixels differ") {
+				t.Errorf("match should report the missing file and the diff, have %q", blue.errors)
+			}
+			for _, suffix := range []string{".actual.png", ".expected.png", ".diff.png"} {
+				if _, e := os.Stat(filepath.Join(match.Dir, "drawing"+suffix)); e != nil {
+					t.Error("failed match should write the image:", e)
+				}
+			}
+		},
+		func(*testing.T, gtk.Widgetter) { inputApp.Win.Close() },
+	)
+}
+
+//
+//-------------------------------------------------------------------[ WIDGET ]--
+
+func init() { flag.BoolVar(&gtkest.Update, "update", false, "update golden files") }
+
+var imageMaker = gtkest.New(inputApp, func() gtk.Widgetter {
+	area := gtk.NewDrawingArea()
+	area.SetContentWidth(40)
+	area.SetContentHeight(30)
+	area.SetDrawFunc(drawColors(1, 0, 0))
+	box := gtk.NewBox(gtk.OrientationVertical, 0)
+	area.SetHAlign(gtk.AlignStart)
+	area.SetVAlign(gtk.AlignStart)
+	box.Append(area)
+	return box
+})
+
+// drawColors draws the left half in the color, and the right half in blue.
+func drawColors(r, g, b float64) gtk.DrawingAreaDrawFunc {
+	return func(_ *gtk.DrawingArea, cr *cairo.Context, width, height int) {
+		cr.SetSourceRGB(r, g, b)
+		cr.Rectangle(0, 0, float64(width/2), float64(height))
+		cr.Fill()
+		cr.SetSourceRGB(0, 0, 1)
+		cr.Rectangle(float64(width/2), 0, float64(width-width/2), float64(height))
+		cr.Fill()
+	}
+}
@@ -0,0 +1,397 @@
+package gtkest
+
+// #cgo pkg-config: gtk4
+// #include <stdlib.h>
+// #include <gtk/gtk.h>
+//
+// static GObject *gtkest_parent(GObject *w) { return G_OBJECT(gtk_widget_get_parent(GTK_WIDGET(w))); }
+//
+// static GObject *gtkest_focus(GObject *w) {
+// 	GtkRoot *root = gtk_widget_get_root(GTK_WIDGET(w));
+// 	return root ? G_OBJECT(gtk_root_get_focus(root)) : NULL;
+// }
+//
+// // gtkest_key emits key-pressed or key-released on the key controller, and
+// // returns whether the key was handled.
+// static gboolean gtkest_key(GObject *ctrl, const char *signal, guint keyval, GdkModifierType state) {
+// 	gboolean handled = FALSE;
+// 	if (g_str_equal(signal, "key-pressed")) {
+// 		g_signal_emit_by_name(ctrl, signal, keyval, 0, state, &handled);
+// 	} else {
+// 		g_signal_emit_by_name(ctrl, signal, keyval, 0, state);
+// 	}
+// 	return handled;
+// }
+//
+// // gtkest_scroll emits scroll on the scroll controller, and returns whether
+// // the scroll was handled.
+// static gboolean gtkest_scroll(GObject *ctrl, double dx, double dy) {
+// 	gboolean handled = FALSE;
+// 	g_signal_emit_by_name(ctrl, "scroll", dx, dy, &handled);
+// 	return handled;
+// }
+import "C"
+
+import (
+	"fmt"
+	"unicode"
+	"unsafe"
+
+	externglib "github.com/diamondburned/gotk4/pkg/core/glib"
+	"github.com/diamondburned/gotk4/pkg/gdk/v4"
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+)
+
+// Input settings.
+var (
+	DragSteps = 5 // Number of drag-update between drag-begin and drag-end.
+)
+
+// Errors formating.
+var (
+	FmtErrAccel = "gtkest: invalid accelerator %q" // Format: accelerator
+)
+
+// Mouse buttons.
+const (
+	ButtonPrimary   = 1
+	ButtonMiddle    = 2
+	ButtonSecondary = 3
+)
+
+// The input functions act like a user on the widget: they emit the signals of
+// the event controllers found on the way of the event, like gtk does.
+//
+// The event is propagated from the top-level widget to the target, to the
+// controllers of the capture phase, then to the target controllers, then back
+// to the top-level for the bubble phase. Insensitive targets get nothing.
+//
+// Coordinates are relative to the widget, and converted for each controller.
+// The widget must be allocated to hit a child: unallocated widgets are their
+// own target, and get all clicks at 0,0.
+//
+// Signals are emitted directly on the controllers, no gdk event is created.
+// Gesture state is not simulated: thresholds, sequences, gesture groups and
+// denied claims are ignored. For clicks and drags, the first widget with a
+// matching gesture in the target and bubble phases claims the event.
+
+//
+//-------------------------------------------------------------------[ MOUSE ]--
+
+// Click clicks the primary button in the middle of the widget.
+//
+// Returns true if a click gesture got the event.
+func Click(w gtk.Widgetter) bool {
+	x, y := center(w)
+	return ClickAt(w, ButtonPrimary, 1, x, y)
+}
+
+// DoubleClick double clicks the primary button in the middle of the widget.
+func DoubleClick(w gtk.Widgetter) bool {
+	x, y := center(w)
+	return ClickAt(w, ButtonPrimary, 2, x, y)
+}
+
+// RightClick clicks the secondary button in the middle of the widget.
+func RightClick(w gtk.Widgetter) bool {
+	x, y := center(w)
+	return ClickAt(w, ButtonSecondary, 1, x, y)
+}
+
+// ClickAt presses and releases the button at the position in the widget, the
+// given number of times, with the press count of gtk.GestureClick.
+//
+// Capture gestures all get the click. In the target and bubble phases, the
+// first widget with a gesture for the button claims it.
+func ClickAt(w gtk.Widgetter, button uint, presses int, x, y float64) bool {
+	target, x, y := pick(w, x, y)
+	var gestures []*gtk.GestureClick
+	for _, ctrl := range controllers(target, claimed(func(ctrl externglib.Objector) bool {
+		g, ok := ctrl.(*gtk.GestureClick)
+		return ok && (g.Button() == 0 || g.Button() == button)
+	})) {
+		gestures = append(gestures, ctrl.(*gtk.GestureClick))
+	}
+
+	for n := 1; n <= presses; n++ {
+		for _, g := range gestures {
+			gx, gy := translate(target, g.Widget(), x, y)
+			g.Emit("pressed", n, gx, gy)
+		}
+		for _, g := range gestures {
+			gx, gy := translate(target, g.Widget(), x, y)
+			g.Emit("released", n, gx, gy)
+		}
+	}
+	for _, g := range gestures {
+		g.Emit("stopped")
+	}
+	return len(gestures) > 0
+}
+
+// Scroll scrolls the widget by the deltas, in steps or pixels depending on the
+// controller. The deltas are filtered by the controller direction flags.
+//
+// Returns true if a scroll controller handled the event.
+func Scroll(w gtk.Widgetter, dx, dy float64) bool {
+	x, y := center(w)
+	target, _, _ := pick(w, x, y)
+	for _, ctrl := range controllers(target, isScroll) {
+		scroll := ctrl.(*gtk.EventControllerScroll)
+		sx, sy := dx, dy
+		if scroll.Flags()&gtk.EventControllerScrollHorizontal == 0 {
+			sx = 0
+		}
+		if scroll.Flags()&gtk.EventControllerScrollVertical == 0 {
+			sy = 0
+		}
+		if sx == 0 && sy == 0 {
+			continue
+		}
+		if C.gtkest_scroll(gobject(scroll), C.double(sx), C.double(sy)) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Drag drags with the primary button from x1,y1 to x2,y2 in the widget, with
+// DragSteps updates.
+//
+// Gestures are claimed like ClickAt: capture gestures all get the drag, then
+// the first widget with a drag gesture.
+//
+// Returns true if a drag gesture got the event.
+func Drag(w gtk.Widgetter, x1, y1, x2, y2 float64) bool {
+	target, x1, y1 := pick(w, x1, y1)
+	x2, y2 = translate(w, target, x2, y2)
+	gestures := controllers(target, claimed(func(ctrl externglib.Objector) bool {
+		g, ok := ctrl.(*gtk.GestureDrag)
+		return ok && (g.Button() == 0 || g.Button() == ButtonPrimary)
+	}))
+	for _, ctrl := range gestures {
+		g := ctrl.(*gtk.GestureDrag)
+		gx, gy := translate(target, g.Widget(), x1, y1)
+		g.Emit("drag-begin", gx, gy)
+		for i := 1; i <= DragSteps; i++ {
+			ratio := float64(i) / float64(DragSteps)
+			g.Emit("drag-update", (x2-x1)*ratio, (y2-y1)*ratio)
+		}
+		g.Emit("drag-end", x2-x1, y2-y1)
+	}
+	return len(gestures) > 0
+}
+
+// claimed returns a controllers filter of the gestures matched: all of them in
+// the capture phase, then only those of the first widget with one, which
+// claims the event.
+func claimed(match func(externglib.Objector) bool) func(externglib.Objector, gtk.PropagationPhase) bool {
+	var claimer gtk.Widgetter // Widget of the first target or bubble gesture.
+	return func(ctrl externglib.Objector, phase gtk.PropagationPhase) bool {
+		if !match(ctrl) {
+			return false
+		}
+		widget := ctrl.(gtk.EventControllerer).Widget()
+		switch {
+		case phase == gtk.PhaseCapture:
+			return true
+		case claimer == nil:
+			claimer = widget
+		}
+		return sameWidget(widget, claimer)
+	}
+}
+
+//
+//----------------------------------------------------------------[ KEYBOARD ]--
+
+// Type focuses the widget and types the text, key by key, on the focus widget.
+//
+// Keys not handled by the key controllers are committed to their input method
+// context, like gtk.Text and gtk.TextView do to insert text.
+func Type(w gtk.Widgetter, text string) {
+	target := keyTarget(w)
+	for _, r := range text {
+		keyval := gdk.UnicodeToKeyval(uint32(r))
+		switch r {
+		case '\n':
+			keyval = gdk.KEY_Return
+		case '\t':
+			keyval = gdk.KEY_Tab
+		}
+		if !pressKey(target, keyval, 0) && unicode.IsPrint(r) {
+			for _, ctrl := range controllers(target, isKey) {
+				if im := ctrl.(*gtk.EventControllerKey).IMContext(); im != nil {
+					externglib.InternObject(im).Emit("commit", string(r))
+					break
+				}
+			}
+		}
+		releaseKey(target, keyval, 0)
+	}
+}
+
+// Press focuses the widget and presses the key combinations on the focus
+// widget, like "<Control>c", "Escape" or "<Shift>Tab". They are delivered to
+// the key controllers and the shortcuts of the widgets on the way.
+//
+// Returns false if a combination was not handled.
+// Panics if an accelerator can't be parsed.
+func Press(w gtk.Widgetter, accels ...string) bool {
+	target := keyTarget(w)
+	handled := true
+	for _, accel := range accels {
+		keyval, mods, ok := gtk.AcceleratorParse(accel)
+		if !ok || keyval == 0 {
+			panic(fmt.Sprintf(FmtErrAccel, accel))
+		}
+		handled = pressKey(target, keyval, mods) && handled
+		releaseKey(target, keyval, mods)
+	}
+	return handled
+}
+
+// keyTarget focuses the widget and returns the focus widget, or the widget
+// itself if it's not in a window.
+func keyTarget(w gtk.Widgetter) gtk.Widgetter {
+	w.GrabFocus()
+	obj := C.gtkest_focus(gobject(w))
+	if obj == nil {
+		return w
+	}
+	return widget(obj)
+}
+
+// pressKey delivers key-pressed and shortcuts until one handles the key.
+func pressKey(target gtk.Widgetter, keyval uint, mods gdk.ModifierType) bool {
+	if !target.IsSensitive() {
+		return false
+	}
+	for _, ctrl := range controllers(target, nil) {
+		switch ctrl := ctrl.(type) {
+		case *gtk.EventControllerKey:
+			if emitKey(ctrl, "key-pressed", keyval, mods) {
+				return true
+			}
+
+		case *gtk.ShortcutController:
+			for i := uint(0); i < ctrl.NItems(); i++ {
+				shortcut, ok := ctrl.Item(i).Cast().(*gtk.Shortcut)
+				if ok && matchTrigger(shortcut.Trigger(), keyval, mods) &&
+					shortcut.Action().Activate(0, ctrl.Widget(), shortcut.Arguments()) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// releaseKey delivers key-released to the key controllers.
+func releaseKey(target gtk.Widgetter, keyval uint, mods gdk.ModifierType) {
+	if !target.IsSensitive() {
+		return
+	}
+	for _, ctrl := range controllers(target, isKey) {
+		emitKey(ctrl.(*gtk.EventControllerKey), "key-released", keyval, mods)
+	}
+}
+
+func emitKey(ctrl *gtk.EventControllerKey, signal string, keyval uint, mods gdk.ModifierType) bool {
+	csignal := C.CString(signal)
+	defer C.free(unsafe.Pointer(csignal))
+	return C.gtkest_key(gobject(ctrl), csignal, C.guint(keyval), C.GdkModifierType(mods)) != 0
+}
+
+// matchTrigger returns true if the shortcut trigger matches the key.
+func matchTrigger(trigger gtk.ShortcutTriggerer, keyval uint, mods gdk.ModifierType) bool {
+	switch trigger := trigger.(type) {
+	case *gtk.KeyvalTrigger:
+		return gdk.KeyvalToLower(trigger.Keyval()) == gdk.KeyvalToLower(keyval) && trigger.Modifiers() == mods
+	case *gtk.AlternativeTrigger:
+		return matchTrigger(trigger.First(), keyval, mods) || matchTrigger(trigger.Second(), keyval, mods)
+	}
+	return false
+}
+
+//
+//-------------------------------------------------------------[ PROPAGATION ]--
+
+// controllers returns the event controllers accepted by the filter, in
+// propagation order for the target. A nil filter accepts all controllers.
+func controllers(target gtk.Widgetter, filter func(ctrl externglib.Objector, phase gtk.PropagationPhase) bool) (list []externglib.Objector) {
+	if !target.IsSensitive() {
+		return nil
+	}
+	path := []gtk.Widgetter{target} // From the target to the top-level.
+	for obj := C.gtkest_parent(gobject(target)); obj != nil; obj = C.gtkest_parent(obj) {
+		path = append(path, widget(obj))
+	}
+
+	add := func(w gtk.Widgetter, phase gtk.PropagationPhase) {
+		model := w.ObserveControllers()
+		for i := uint(0); i < model.NItems(); i++ {
+			ctrl := model.Item(i).Cast()
+			base, ok := ctrl.(interface{ PropagationPhase() gtk.PropagationPhase })
+			if ok && base.PropagationPhase() == phase && (filter == nil || filter(ctrl, phase)) {
+				list = append(list, ctrl)
+			}
+		}
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		add(path[i], gtk.PhaseCapture)
+	}
+	add(target, gtk.PhaseTarget)
+	for _, w := range path {
+		add(w, gtk.PhaseBubble)
+	}
+	return list
+}
+
+func isKey(ctrl externglib.Objector, _ gtk.PropagationPhase) bool {
+	_, ok := ctrl.(*gtk.EventControllerKey)
+	return ok
+}
+
+func isScroll(ctrl externglib.Objector, _ gtk.PropagationPhase) bool {
+	_, ok := ctrl.(*gtk.EventControllerScroll)
+	return ok
+}
+
+// pick returns the deepest widget at the position, and the position in it.
+func pick(w gtk.Widgetter, x, y float64) (gtk.Widgetter, float64, float64) {
+	target := w.Pick(x, y, gtk.PickDefault)
+	if target == nil {
+		return w, x, y
+	}
+	x, y = translate(w, target, x, y)
+	return target, x, y
+}
+
+// translate converts the position from the src to the dest widget.
+func translate(src, dest gtk.Widgetter, x, y float64) (float64, float64) {
+	if dx, dy, ok := src.TranslateCoordinates(dest, x, y); ok {
+		return dx, dy
+	}
+	return x, y
+}
+
+func center(w gtk.Widgetter) (float64, float64) {
+	return float64(w.AllocatedWidth()) / 2, float64(w.AllocatedHeight()) / 2
+}
+
+func sameWidget(a, b gtk.Widgetter) bool { return gobject(a) == gobject(b) }
+
+func gobject(obj externglib.Objector) *C.GObject {
+	return (*C.GObject)(unsafe.Pointer(externglib.InternObject(obj).Native()))
+}
+
+// widget wraps the native widget. Windows are not gtk.Widgetter in gotk4, so
+// they are used as their base gtk.Widget.
+func widget(obj *C.GObject) gtk.Widgetter {
+	base := externglib.Take(unsafe.Pointer(obj))
+	if w, ok := base.Cast().(gtk.Widgetter); ok {
+		return w
+	}
+	return &gtk.Widget{InitiallyUnowned: externglib.InitiallyUnowned{Object: base}, Object: base}
+}
@@ -0,0 +1,154 @@
+package gtkest_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/gotk4/pkg/glib/v2"
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+
+	"github.com/gtkool4/grun"
+	"github.com/gtkool4/gtkelp/gtkest"
+	"github.com/gtkool4/gtkelp/gtknew"
+)
+
+func TestInput(t *testing.T) {
+	for name, call := range inputTests {
+		t.Run(name, func(t *testing.T) {
+			inputMaker.Run(t,
+				func(t *testing.T, w gtk.Widgetter) { call(t, w.(*inputWidget)) },
+				func(*testing.T, gtk.Widgetter) { inputApp.Win.Close() },
+			)
+		})
+	}
+}
+
+var inputTests = map[string]func(t *testing.T, w *inputWidget){
+	"Click": func(t *testing.T, w *inputWidget) {
+		if !gtkest.Click(w.pad) || !gtkest.DoubleClick(w.pad) {
+			t.Error("click should be handled by the pad gesture")
+		}
+		testEvents(t, w, "capture 1", "pressed 1", "released 1", "capture 1", "pressed 1", "released 1", "capture 2", "pressed 2", "released 2")
+	},
+	"RightClick": func(t *testing.T, w *inputWidget) {
+		gtkest.RightClick(w.pad) // Primary button gestures don't get it.
+		testEvents(t, w)
+	},
+	"Focus": func(t *testing.T, w *inputWidget) {
+		gtkest.Type(w.entry, "Hello")
+		if w.entry.StateFlags()&gtk.StateFlagFocusWithin == 0 {
+			t.Error("typing should focus the entry")
+		}
+		gtkest.Click(w.button)
+		if !w.button.HasFocus() || w.entry.StateFlags()&gtk.StateFlagFocusWithin != 0 {
+			t.Error("click should move the focus to the button")
+		}
+	},
+	"Type": func(t *testing.T, w *inputWidget) {
+		gtkest.Type(w.entry, "Hello GTK4")
+		if w.entry.Text() != "Hello GTK4" {
+			t.Errorf("entry text should be \"Hello GTK4\" but we have %q", w.entry.Text())
+		}
+		if !gtkest.Press(w.entry, "BackSpace", "BackSpace") || w.entry.Text() != "Hello GT" {
+			t.Errorf("BackSpace should delete 2 chars but we have %q", w.entry.Text())
+		}
+	},
+	"Shortcut": func(t *testing.T, w *inputWidget) {
+		if !gtkest.Press(w.pad, "<Control>s") {
+			t.Error("<Control>s should be handled by the shortcut")
+		}
+		if gtkest.Press(w.pad, "<Control>x") {
+			t.Error("<Control>x should not be handled")
+		}
+		testEvents(t, w, "saved")
+	},
+	"Scroll": func(t *testing.T, w *inputWidget) {
+		if !gtkest.Scroll(w.pad, 0, 2) {
+			t.Error("vertical scroll should be handled")
+		}
+		if gtkest.Scroll(w.pad, 3, 0) {
+			t.Error("horizontal scroll should be filtered")
+		}
+		testEvents(t, w, "scroll 0 2")
+	},
+	"Drag": func(t *testing.T, w *inputWidget) {
+		defer func(steps int) { gtkest.DragSteps = steps }(gtkest.DragSteps)
+		gtkest.DragSteps = 2
+		if !gtkest.Drag(w.pad, 1, 1, 11, 21) {
+			t.Error("drag should be handled by the pad gesture")
+		}
+		testEvents(t, w, "begin 1 1", "update 5 10", "update 10 20", "end 10 20")
+	},
+}
+
+//
+//-------------------------------------------------------------------[ WIDGET ]--
+
+// inputWidget records the events of controllers on its pad.
+type inputWidget struct {
+	*gtk.Box
+	pad    *gtk.Box
+	entry  *gtk.Entry
+	button *gtk.Button
+	events []string
+}
+
+var inputApp = grun.NewSized(300, 200, grun.SetFmtTitleTest())
+
+var inputMaker = gtkest.New(inputApp, func() gtk.Widgetter { return newInputWidget() })
+
+func newInputWidget() *inputWidget {
+	w := &inputWidget{
+		pad:    gtk.NewBox(gtk.OrientationVertical, 0),
+		entry:  gtk.NewEntry(),
+		button: gtk.NewButtonWithLabel("button"),
+	}
+	w.pad.SetSizeRequest(100, 100)
+	w.Box = gtknew.VBox(0, w.pad, w.entry, w.button)
+
+	capture := gtk.NewGestureClick()
+	capture.SetPropagationPhase(gtk.PhaseCapture)
+	capture.Connect("pressed", func(_ *gtk.GestureClick, n int) { w.record("capture", n) })
+	w.Box.AddController(capture)
+
+	click := gtk.NewGestureClick()
+	click.Connect("pressed", func(_ *gtk.GestureClick, n int) { w.record("pressed", n) })
+	click.Connect("released", func(_ *gtk.GestureClick, n int) { w.record("released", n) })
+	w.pad.AddController(click)
+
+	scroll := gtk.NewEventControllerScroll(gtk.EventControllerScrollVertical)
+	scroll.Connect("scroll", func(_ *gtk.EventControllerScroll, dx, dy float64) bool {
+		w.record("scroll", dx, dy)
+		return true
+	})
+	w.pad.AddController(scroll)
+
+	drag := gtk.NewGestureDrag()
+	drag.Connect("drag-begin", func(_ *gtk.GestureDrag, x, y float64) { w.record("begin", x, y) })
+	drag.Connect("drag-update", func(_ *gtk.GestureDrag, x, y float64) { w.record("update", x, y) })
+	drag.Connect("drag-end", func(_ *gtk.GestureDrag, x, y float64) { w.record("end", x, y) })
+	w.pad.AddController(drag)
+
+	outer := gtk.NewGestureDrag() // Never reached: the pad claims the drag.
+	outer.Connect("drag-begin", func(_ *gtk.GestureDrag, x, y float64) { w.record("outer", x, y) })
+	w.Box.AddController(outer)
+
+	shortcuts := gtk.NewShortcutController()
+	shortcuts.AddShortcut(gtk.NewShortcut(
+		gtk.NewShortcutTriggerParseString("<Control>s"),
+		gtk.NewCallbackAction(func(gtk.Widgetter, *glib.Variant) bool { w.record("saved"); return true }),
+	))
+	w.pad.AddController(shortcuts)
+	return w
+}
+
+func (w *inputWidget) record(event string, values ...interface{}) {
+	w.events = append(w.events, strings.TrimSpace(fmt.Sprintln(append([]interface{}{event}, values...)...)))
+}
+
+func testEvents(t *testing.T, w *inputWidget, expected ...string) {
+	if strings.Join(w.events, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("events should be %q but we have %q", expected, w.events)
+	}
+}
@@ -0,0 +1,227 @@
+package gtkest
+
+// #include <stdlib.h>
+// #include <gtk/gtk.h>
+//
+// static gboolean gtkest_is_a(GObject *obj, const char *name) {
+// 	GType type = g_type_from_name(name);
+// 	return type && G_TYPE_CHECK_INSTANCE_TYPE(obj, type);
+// }
+//
+// // gtkest_string returns the value of the string property, or NULL if the
+// // object has none. Free with g_free.
+// static char *gtkest_string(GObject *obj, const char *name) {
+// 	GParamSpec *spec = g_object_class_find_property(G_OBJECT_GET_CLASS(obj), name);
+// 	char *str = NULL;
+// 	if (spec && spec->value_type == G_TYPE_STRING && (spec->flags & G_PARAM_READABLE)) {
+// 		g_object_get(obj, name, &str, NULL);
+// 	}
+// 	return str;
+// }
+//
+// static gboolean gtkest_bool(GObject *obj, const char *name) {
+// 	GParamSpec *spec = g_object_class_find_property(G_OBJECT_GET_CLASS(obj), name);
+// 	gboolean value = FALSE;
+// 	if (spec && spec->value_type == G_TYPE_BOOLEAN && (spec->flags & G_PARAM_READABLE)) {
+// 		g_object_get(obj, name, &value, NULL);
+// 	}
+// 	return value;
+// }
+import "C"
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+)
+
+// Query settings.
+var (
+	FindTimeout = time.Second // Wait of Find.
+)
+
+// Errors formating.
+var (
+	FmtErrQuery = "gtkest: found %d %s with %s, want 1" // Format: count, type, queries
+	TxtAnyQuery = "no query"
+)
+
+//
+//-------------------------------------------------------------------[ QUERY ]--
+
+// Query defines a widget search criteria.
+type Query struct {
+	desc  string
+	match func(w gtk.Widgetter) bool
+}
+
+// String describes the query, for errors.
+func (q Query) String() string { return q.desc }
+
+// ByType matches widgets of the GType or a subtype, like "GtkButton".
+func ByType(gtype string) Query {
+	return Query{
+		desc: "type " + gtype,
+		match: func(w gtk.Widgetter) bool {
+			cname := C.CString(gtype)
+			defer C.free(unsafe.Pointer(cname))
+			return C.gtkest_is_a(gobject(w), cname) != 0
+		},
+	}
+}
+
+// ByName matches widgets by name, set with SetName or the name property.
+func ByName(name string) Query {
+	return Query{
+		desc:  fmt.Sprintf("name %q", name),
+		match: func(w gtk.Widgetter) bool { return w.Name() == name },
+	}
+}
+
+// ByClass matches widgets having the CSS class.
+func ByClass(class string) Query {
+	return Query{
+		desc:  "class " + class,
+		match: func(w gtk.Widgetter) bool { return w.HasCSSClass(class) },
+	}
+}
+
+// ByRole matches widgets by accessible role.
+func ByRole(role gtk.AccessibleRole) Query {
+	return Query{
+		desc: "role " + role.String(),
+		match: func(w gtk.Widgetter) bool {
+			a, ok := w.(interface{ AccessibleRole() gtk.AccessibleRole })
+			return ok && a.AccessibleRole() == role
+		},
+	}
+}
+
+// ByLabel matches widgets by accessible label: the text of their mnemonic
+// labels, or their own label property, without mnemonic underscores.
+func ByLabel(label string) Query {
+	return Query{
+		desc:  fmt.Sprintf("label %q", label),
+		match: func(w gtk.Widgetter) bool { return AccessibleLabel(w) == label },
+	}
+}
+
+// ByText matches gtk.Label widgets by displayed text.
+func ByText(text string) Query {
+	return Query{
+		desc: fmt.Sprintf("text %q", text),
+		match: func(w gtk.Widgetter) bool {
+			label, ok := w.(*gtk.Label)
+			return ok && label.Text() == text
+		},
+	}
+}
+
+// ByTooltip matches widgets by tooltip text.
+func ByTooltip(text string) Query {
+	return Query{
+		desc:  fmt.Sprintf("tooltip %q", text),
+		match: func(w gtk.Widgetter) bool { return w.TooltipText() == text },
+	}
+}
+
+// AccessibleLabel returns the label of the widget used by ByLabel.
+func AccessibleLabel(w gtk.Widgetter) string {
+	for _, mnemo := range w.ListMnemonicLabels() {
+		if label, ok := mnemo.(*gtk.Label); ok {
+			return label.Text()
+		}
+	}
+	if label, ok := w.(*gtk.Label); ok {
+		return label.Text()
+	}
+	label := stringProperty(w, "label")
+	if boolProperty(w, "use-underline") {
+		label = strings.ReplaceAll(strings.ReplaceAll(label, "__", "\x00"), "_", "")
+		label = strings.ReplaceAll(label, "\x00", "_")
+	}
+	return label
+}
+
+//
+//------------------------------------------------------------------[ SEARCH ]--
+
+// QueryAll returns the widgets of type T matching all queries, in the tree of
+// root including itself, in depth-first order.
+func QueryAll[T gtk.Widgetter](root gtk.Widgetter, queries ...Query) (list []T) {
+	walk(root, func(w gtk.Widgetter) {
+		typed, ok := w.(T)
+		if !ok {
+			return
+		}
+		for _, q := range queries {
+			if !q.match(w) {
+				return
+			}
+		}
+		list = append(list, typed)
+	})
+	return list
+}
+
+// Get returns the only widget of type T matching all queries. If none or many
+// are found, the test gets an error and the zero T is returned.
+func Get[T gtk.Widgetter](t testing.TB, root gtk.Widgetter, queries ...Query) T {
+	t.Helper()
+	var zero T
+	list := QueryAll[T](root, queries...)
+	if len(list) != 1 {
+		t.Errorf(FmtErrQuery, len(list), reflect.TypeOf((*T)(nil)).Elem(), describe(queries))
+		return zero
+	}
+	return list[0]
+}
+
+// Find waits for the only widget of type T matching all queries, running the
+// main loop up to FindTimeout. The widget is checked like Get.
+func Find[T gtk.Widgetter](t testing.TB, root gtk.Widgetter, queries ...Query) T {
+	t.Helper()
+	iterate(FindTimeout, func() bool { return len(QueryAll[T](root, queries...)) == 1 })
+	return Get[T](t, root, queries...)
+}
+
+func describe(queries []Query) string {
+	if len(queries) == 0 {
+		return TxtAnyQuery
+	}
+	list := make([]string, len(queries))
+	for i, q := range queries {
+		list[i] = q.String()
+	}
+	return strings.Join(list, " and ")
+}
+
+// walk calls the function for the widget and all its descendants.
+func walk(w gtk.Widgetter, call func(gtk.Widgetter)) {
+	call(w)
+	for child := w.FirstChild(); child != nil; child = child.NextSibling() {
+		walk(child, call)
+	}
+}
+
+func stringProperty(w gtk.Widgetter, name string) string {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	cstr := C.gtkest_string(gobject(w), cname)
+	if cstr == nil {
+		return ""
+	}
+	defer C.g_free(C.gpointer(cstr))
+	return C.GoString(cstr)
+}
+
+func boolProperty(w gtk.Widgetter, name string) bool {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	return C.gtkest_bool(gobject(w), cname) != 0
+}
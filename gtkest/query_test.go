@@ -0,0 +1,122 @@
+package gtkest_test
+
+import (
+	"fmt"
+	"testing"
+
+	externglib "github.com/diamondburned/gotk4/pkg/core/glib"
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+
+	"github.com/gtkool4/gtkelp/gtkest"
+	"github.com/gtkool4/gtkelp/gtknew"
+)
+
+func TestQuery(t *testing.T) {
+	for name, call := range queryTests {
+		t.Run(name, func(t *testing.T) {
+			queryMaker.Run(t,
+				func(t *testing.T, w gtk.Widgetter) { call(t, w.(*gtk.Box)) },
+				func(*testing.T, gtk.Widgetter) { inputApp.Win.Close() },
+			)
+		})
+	}
+}
+
+var queryTests = map[string]func(t *testing.T, w *gtk.Box){
+	"All": func(t *testing.T, w *gtk.Box) {
+		testCount(t, len(gtkest.QueryAll[*gtk.Button](w)), 3)
+		testCount(t, len(gtkest.QueryAll[gtk.Widgetter](w, gtkest.ByType("GtkButton"))), 4) // With the toggle.
+		testCount(t, len(gtkest.QueryAll[gtk.Widgetter](w, gtkest.ByClass("suggested-action"))), 2)
+		testCount(t, len(gtkest.QueryAll[*gtk.Label](w)), 6) // With buttons labels.
+		testCount(t, len(gtkest.QueryAll[*gtk.Entry](w, gtkest.ByName("missing"))), 0)
+	},
+	"Get": func(t *testing.T, w *gtk.Box) {
+		ok := gtkest.Get[*gtk.Button](t, w, gtkest.ByLabel("OK"))
+		if ok == nil || ok.Label() != "_OK" {
+			t.Error("Get should find the OK button")
+		}
+		apply := gtkest.Get[*gtk.Button](t, w, gtkest.ByClass("suggested-action"), gtkest.ByTooltip("Apply changes"))
+		if apply == nil || apply.Label() != "Apply" {
+			t.Error("Get should find the Apply button by class and tooltip")
+		}
+		if gtkest.Get[*gtk.Entry](t, w, gtkest.ByLabel("Search:")) == nil {
+			t.Error("Get should find the entry by its mnemonic label")
+		}
+		if gtkest.Get[*gtk.Entry](t, w, gtkest.ByName("search")) == nil {
+			t.Error("Get should find the entry by name")
+		}
+		if gtkest.Get[*gtk.Label](t, w, gtkest.ByText("Status ready")) == nil {
+			t.Error("Get should find the label by its text without markup")
+		}
+		if gtkest.Get[*gtk.Entry](t, w, gtkest.ByRole(gtk.AccessibleRoleTextBox)) == nil {
+			t.Error("Get should find the entry by role")
+		}
+	},
+	"GetError": func(t *testing.T, w *gtk.Box) {
+		fake := &fakeT{TB: t}
+		if gtkest.Get[*gtk.Button](fake, w, gtkest.ByClass("suggested-action")) != nil {
+			t.Error("Get should return nil with many widgets")
+		}
+		gtkest.Get[gtk.Widgetter](fake, w, gtkest.ByText("missing"))
+		testExpected(t, fake.errors, []string{
+			"gtkest: found 2 *gtk.Button with class suggested-action, want 1",
+			`gtkest: found 0 gtk.Widgetter with text "missing", want 1`,
+		})
+	},
+	"Find": func(t *testing.T, w *gtk.Box) {
+		externglib.TimeoutAdd(50, func() bool {
+			w.Append(gtk.NewLabel("Loaded"))
+			return false
+		})
+		if gtkest.Find[*gtk.Label](t, w, gtkest.ByText("Loaded")) == nil {
+			t.Error("Find should wait for the label")
+		}
+	},
+}
+
+//
+//-------------------------------------------------------------------[ WIDGET ]--
+
+var queryMaker = gtkest.New(inputApp, func() gtk.Widgetter { return newQueryWidget() })
+
+func newQueryWidget() *gtk.Box {
+	search := gtk.NewEntry()
+	search.SetName("search")
+	label := gtk.NewLabelWithMnemonic("_Search:")
+	label.SetMnemonicWidget(search)
+
+	ok := gtk.NewButtonWithMnemonic("_OK")
+	ok.AddCSSClass("suggested-action")
+	apply := gtk.NewButtonWithLabel("Apply")
+	apply.AddCSSClass("suggested-action")
+	apply.SetTooltipText("Apply changes")
+
+	status := gtknew.LabelWithMarkup("Status <b>ready</b>")
+	return gtknew.VBox(0, label, search, ok, apply, gtk.NewButtonWithLabel("Cancel"), gtk.NewToggleButtonWithLabel("Lock"), status)
+}
+
+// fakeT records test errors.
+type fakeT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) Error(args ...interface{}) { t.errors = append(t.errors, fmt.Sprint(args...)) }
+
+func testCount(t *testing.T, have, want int) {
+	t.Helper()
+	if have != want {
+		t.Errorf("query should find %d widgets but we have %d", want, have)
+	}
+}
+
+func testExpected(t *testing.T, have, want []string) {
+	t.Helper()
+	if fmt.Sprint(have) != fmt.Sprint(want) {
+		t.Errorf("errors should be %q but we have %q", want, have)
+	}
+}
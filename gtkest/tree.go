@@ -0,0 +1,290 @@
+package gtkest
+
+// #include <stdlib.h>
+// #include <gtk/gtk.h>
+//
+// static const char *gtkest_type(GObject *obj) { return G_OBJECT_TYPE_NAME(obj); }
+//
+// static void gtkest_allocation(GObject *w, int *x, int *y, int *width, int *height) {
+// 	GtkAllocation alloc;
+// 	gtk_widget_get_allocation(GTK_WIDGET(w), &alloc);
+// 	*x = alloc.x;
+// 	*y = alloc.y;
+// 	*width = alloc.width;
+// 	*height = alloc.height;
+// }
+//
+// // gtkest_property formats the property value: enums as nick, booleans as
+// // true or false. Returns NULL if the object has no such readable property,
+// // or for an empty string. quote is set for strings. Free with g_free.
+// static char *gtkest_property(GObject *obj, const char *name, gboolean *quote) {
+// 	GParamSpec *spec = g_object_class_find_property(G_OBJECT_GET_CLASS(obj), name);
+// 	if (!spec || !(spec->flags & G_PARAM_READABLE)) {
+// 		return NULL;
+// 	}
+// 	GValue value = G_VALUE_INIT;
+// 	g_value_init(&value, spec->value_type);
+// 	g_object_get_property(obj, name, &value);
+// 	char *str = NULL;
+// 	*quote = FALSE;
+// 	if (G_VALUE_HOLDS_STRING(&value)) {
+// 		const char *s = g_value_get_string(&value);
+// 		str = s && *s ? g_strdup(s) : NULL;
+// 		*quote = TRUE;
+// 	} else if (G_VALUE_HOLDS_BOOLEAN(&value)) {
+// 		str = g_strdup(g_value_get_boolean(&value) ? "true" : "false");
+// 	} else if (G_VALUE_HOLDS_ENUM(&value)) {
+// 		GEnumClass *class = g_type_class_ref(spec->value_type);
+// 		GEnumValue *e = g_enum_get_value(class, g_value_get_enum(&value));
+// 		str = e ? g_strdup(e->value_nick) : g_strdup_printf("%d", g_value_get_enum(&value));
+// 		g_type_class_unref(class);
+// 	} else {
+// 		str = g_strdup_value_contents(&value);
+// 	}
+// 	g_value_unset(&value);
+// 	return str;
+// }
+import "C"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+)
+
+// Tree settings.
+var (
+	TreeDir        = "testdata" // Golden files directory.
+	TreeIndent     = "  "
+	TreeAllocation = true // Dump allocations. They depend on fonts and themes.
+	TreeProperties = []string{"label", "text", "title", "icon-name", "orientation", "active"}
+	DiffContext    = 3 // Lines around changes in diffs.
+)
+
+// Errors formating.
+var (
+	FmtErrTree     = "gtkest: tree %s: %s"                       // Format: file, message
+	FmtErrTreeDiff = "gtkest: tree %s differs, -want +have:\n%s" // Format: file, unified diff
+)
+
+//
+//--------------------------------------------------------------------[ DUMP ]--
+
+// DumpTree returns the widget tree as text, one line by widget, indented by
+// depth:
+//
+//   GtkButton#name.class1.class2 hidden insensitive label="OK" @0,40 80x34
+//
+// The line has the GType, the name if set, the sorted CSS classes, the hidden
+// and insensitive states, the TreeProperties of the widget with a value, and
+// the allocation relative to the parent if TreeAllocation is set.
+func DumpTree(w gtk.Widgetter) string {
+	var buf strings.Builder
+	dumpTree(&buf, w, 0)
+	return buf.String()
+}
+
+func dumpTree(buf *strings.Builder, w gtk.Widgetter, depth int) {
+	gtype := C.GoString(C.gtkest_type(gobject(w)))
+	buf.WriteString(strings.Repeat(TreeIndent, depth) + gtype)
+	if name := w.Name(); name != gtype {
+		buf.WriteString("#" + name)
+	}
+	classes := w.CSSClasses()
+	sort.Strings(classes)
+	for _, class := range classes {
+		buf.WriteString("." + class)
+	}
+	if !w.Visible() {
+		buf.WriteString(" hidden")
+	}
+	if !w.Sensitive() {
+		buf.WriteString(" insensitive")
+	}
+	for _, name := range TreeProperties {
+		if value, ok := property(w, name); ok {
+			buf.WriteString(" " + name + "=" + value)
+		}
+	}
+	if TreeAllocation {
+		var x, y, width, height C.int
+		C.gtkest_allocation(gobject(w), &x, &y, &width, &height)
+		fmt.Fprintf(buf, " @%d,%d %dx%d", x, y, width, height)
+	}
+	buf.WriteString("\n")
+
+	for child := w.FirstChild(); child != nil; child = child.NextSibling() {
+		dumpTree(buf, child, depth+1)
+	}
+}
+
+// property returns the formatted value of the widget property, if set.
+func property(w gtk.Widgetter, name string) (string, bool) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	var quote C.gboolean
+	cstr := C.gtkest_property(gobject(w), cname, &quote)
+	if cstr == nil {
+		return "", false
+	}
+	defer C.g_free(C.gpointer(cstr))
+	if quote != 0 {
+		return strconv.Quote(C.GoString(cstr)), true
+	}
+	return C.GoString(cstr), true
+}
+
+//
+//-------------------------------------------------------------------[ MATCH ]--
+
+// MatchTree compares the widget tree dump to the golden file name.tree in
+// TreeDir, and reports a unified diff. If Update is set, the golden file is
+// written instead.
+func MatchTree(t testing.TB, w gtk.Widgetter, name string) {
+	t.Helper()
+	file := filepath.Join(TreeDir, name+".tree")
+	have := DumpTree(w)
+	if Update {
+		e := os.MkdirAll(TreeDir, 0o755)
+		if e == nil {
+			e = os.WriteFile(file, []byte(have), 0o644)
+		}
+		if e != nil {
+			t.Errorf(FmtErrTree, file, e)
+		}
+		return
+	}
+
+	want, e := os.ReadFile(file)
+	if e != nil {
+		t.Errorf(FmtErrTree, file, fmt.Sprintf("%s, %s", e, TxtErrImageRerun))
+		return
+	}
+	if have != string(want) {
+		t.Errorf(FmtErrTreeDiff, file, Diff(file, "have", string(want), have))
+	}
+}
+
+//
+//--------------------------------------------------------------------[ DIFF ]--
+
+// Diff returns the unified diff of the texts, by line, with DiffContext lines
+// around changes. Returns an empty string if they are equal.
+func Diff(nameA, nameB, a, b string) string {
+	ops := diffLines(splitLines(a), splitLines(b))
+	var buf strings.Builder
+	for i, stop := 0, 0; i < len(ops); i++ {
+		if ops[i].kind == ' ' {
+			continue
+		}
+		if buf.Len() == 0 {
+			fmt.Fprintf(&buf, "--- %s\n+++ %s\n", nameA, nameB)
+		}
+
+		// Merge changes closer than two contexts in the hunk.
+		start := maxInt(i-DiffContext, stop)
+		end := i
+		for j := i; j < len(ops) && j-end <= 2*DiffContext; j++ {
+			if ops[j].kind != ' ' {
+				end = j
+			}
+		}
+		stop = minInt(end+DiffContext+1, len(ops))
+
+		hunk := ops[start:stop]
+		countA, countB := 0, 0
+		for _, op := range hunk {
+			if op.kind != '+' {
+				countA++
+			}
+			if op.kind != '-' {
+				countB++
+			}
+		}
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n", hunkRange(hunk[0].a, countA), hunkRange(hunk[0].b, countB))
+		for _, op := range hunk {
+			buf.WriteString(string(op.kind) + op.text + "\n")
+		}
+		i = stop - 1
+	}
+	return buf.String()
+}
+
+// diffOp is a diff line: ' ' kept, '-' removed or '+' added, with the index
+// of the line in each text at this point.
+type diffOp struct {
+	kind byte
+	text string
+	a, b int
+}
+
+// diffLines returns the edit script from a to b, from their longest common
+// subsequence.
+func diffLines(a, b []string) (ops []diffOp) {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = maxInt(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i], i, j})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{'-', a[i], i, j})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j], i, j})
+			j++
+		}
+	}
+	return ops
+}
+
+// hunkRange formats a unified diff range from a line index and count.
+func hunkRange(index, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", index)
+	}
+	return fmt.Sprintf("%d,%d", index+1, count)
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,57 @@
+package gtkest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+
+	"github.com/gtkool4/gtkelp/gtkest"
+	"github.com/gtkool4/gtkelp/gtknew"
+)
+
+func TestMatchTree(t *testing.T) {
+	defer func(alloc bool) { gtkest.TreeAllocation = alloc }(gtkest.TreeAllocation)
+	gtkest.TreeAllocation = false // Depends on fonts.
+
+	treeMaker.Run(t,
+		func(t *testing.T, w gtk.Widgetter) {
+			gtkest.MatchTree(t, w, "widgets")
+			if gtkest.Update {
+				return
+			}
+
+			gtkest.Get[*gtk.Label](t, w, gtkest.ByName("title")).SetLabel("Changed")
+			fake := &fakeT{TB: t}
+			gtkest.MatchTree(fake, w, "widgets")
+			testExpected(t, fake.errors, []string{strings.Join([]string{
+				"gtkest: tree testdata/widgets.tree differs, -want +have:",
+				"--- testdata/widgets.tree",
+				"+++ have",
+				"@@ -1,5 +1,5 @@",
+				" GtkBox.vertical orientation=vertical",
+				`-  GtkLabel#title label="Hello"`,
+				`+  GtkLabel#title label="Changed"`,
+				`   GtkButton#ok.suggested-action.text-button label="OK"`,
+				`     GtkLabel label="OK"`,
+				`   GtkLabel hidden label="hidden"`,
+				"",
+			}, "\n")})
+		},
+		func(*testing.T, gtk.Widgetter) { inputApp.Win.Close() },
+	)
+}
+
+var treeMaker = gtkest.New(inputApp, func() gtk.Widgetter {
+	title := gtk.NewLabel("Hello")
+	title.SetName("title")
+	ok := gtk.NewButtonWithLabel("OK")
+	ok.SetName("ok")
+	ok.AddCSSClass("suggested-action")
+	hidden := gtk.NewLabel("hidden")
+	hidden.Hide()
+	lock := gtk.NewToggleButtonWithLabel("Lock")
+	lock.SetActive(true)
+	lock.SetSensitive(false)
+	return gtknew.VBox(0, title, ok, hidden, lock)
+})
@@ -0,0 +1,74 @@
+package gtkest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	externglib "github.com/diamondburned/gotk4/pkg/core/glib"
+)
+
+// Errors formating.
+var (
+	FmtErrWait       = "gtkest: not done after %s: %s"       // Format: timeout, last state
+	FmtErrWaitSignal = "gtkest: no signal %s on %T after %s" // Format: signal, object, timeout
+	FmtErrEqual      = "have %#v, want %#v"                  // Format: have, want
+)
+
+//
+//--------------------------------------------------------------------[ WAIT ]--
+
+// Wait runs the main loop until the condition returns nil, up to timeout.
+//
+// Returns an error with the last condition error, describing the state.
+func Wait(timeout time.Duration, cond func() error) error {
+	var last error
+	if iterate(timeout, func() bool { last = cond(); return last == nil }) {
+		return nil
+	}
+	return fmt.Errorf(FmtErrWait, timeout, last)
+}
+
+// Eventually runs the main loop until the condition returns nil, up to
+// timeout. Else the test gets an error with the last condition error.
+//
+// Use it in callbacks of the main loop, like Maker.Run tests:
+//
+//   gtkest.Click(w.paste)
+//   gtkest.Eventually(t, func() error { return gtkest.Equal(w.Text(), "pasted") }, time.Second)
+//
+func Eventually(t testing.TB, cond func() error, timeout time.Duration) bool {
+	t.Helper()
+	if e := Wait(timeout, cond); e != nil {
+		t.Error(e)
+		return false
+	}
+	return true
+}
+
+// WaitSignal runs the main loop until the object emits the signal, up to
+// timeout. Else the test gets an error.
+//
+// Only emissions after the call are seen: start the action before, and let it
+// complete in the main loop.
+func WaitSignal(t testing.TB, obj externglib.Objector, signal string, timeout time.Duration) bool {
+	t.Helper()
+	emitted := false
+	handle := obj.Connect(signal, func() { emitted = true })
+	defer obj.HandlerDisconnect(handle)
+	if !iterate(timeout, func() bool { return emitted }) {
+		t.Errorf(FmtErrWaitSignal, signal, obj, timeout)
+		return false
+	}
+	return true
+}
+
+// Equal returns nil if the values are deeply equal, or an error describing
+// them, for Wait and Eventually conditions.
+func Equal(have, want interface{}) error {
+	if reflect.DeepEqual(have, want) {
+		return nil
+	}
+	return fmt.Errorf(FmtErrEqual, have, want)
+}
@@ -0,0 +1,45 @@
+package gtkest_test
+
+import (
+	"testing"
+	"time"
+
+	externglib "github.com/diamondburned/gotk4/pkg/core/glib"
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+
+	"github.com/gtkool4/gtkelp/gtkest"
+)
+
+func TestWait(t *testing.T) {
+	waitMaker.Run(t,
+		func(t *testing.T, w gtk.Widgetter) {
+			button := w.(*gtk.Button)
+			externglib.TimeoutAdd(20, func() bool {
+				button.SetLabel("done")
+				return false
+			})
+			if !gtkest.Eventually(t, func() error { return gtkest.Equal(button.Label(), "done") }, time.Second) {
+				t.Error("Eventually should see the label change")
+			}
+
+			externglib.TimeoutAdd(20, func() bool {
+				button.Emit("clicked")
+				return false
+			})
+			if !gtkest.WaitSignal(t, button, "clicked", time.Second) {
+				t.Error("WaitSignal should see the click")
+			}
+
+			fake := &fakeT{TB: t}
+			gtkest.Eventually(fake, func() error { return gtkest.Equal(button.Label(), "never") }, 50*time.Millisecond)
+			gtkest.WaitSignal(fake, button, "clicked", 50*time.Millisecond)
+			testExpected(t, fake.errors, []string{
+				`gtkest: not done after 50ms: have "done", want "never"`,
+				"gtkest: no signal clicked on *gtk.Button after 50ms",
+			})
+		},
+		func(*testing.T, gtk.Widgetter) { inputApp.Win.Close() },
+	)
+}
+
+var waitMaker = gtkest.New(inputApp, func() gtk.Widgetter { return gtk.NewButtonWithLabel("waiting") })
@@ -49,6 +49,17 @@ func Underline(text string) string { return tag("u", text) }
 
 func tag(tagl, text string) string { return "<" + tagl + ">" + text + "</" + tagl + ">" }
 
+//
+//---------------------------------------------------------------[ TRANSLATE ]--
+
+// Translate translates the strings of Tr. Strings are unchanged until it is set,
+// see buildhelp.LoadTranslations.
+var Translate = func(msg string) string { return msg }
+
+// Tr translates the message with Translate. Literal messages are extracted by
+// uixgettext.
+func Tr(msg string) string { return Translate(msg) }
+
 //
 //------------------------------------------------------------------[ OTHERS ]--
 
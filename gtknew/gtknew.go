@@ -81,7 +81,8 @@ func VPaned(left, right gtk.Widgetter) *gtk.Paned {
 	return box
 }
 
-// Expander creates a *gtk.Expander with an optional child widget.
+// Expander creates a *gtk.Expander with an optional child widget. The name is
+// used as is, translate it with gtkext.Tr.
 func Expander(name string, w ...gtk.Widgetter) gtk.Widgetter {
 	exp := gtk.NewExpander(name)
 	if len(w) > 0 && w[0] != nil {
@@ -90,7 +91,8 @@ func Expander(name string, w ...gtk.Widgetter) gtk.Widgetter {
 	return exp
 }
 
-// Frame creates a *gtk.Frame with an optional child widget.
+// Frame creates a *gtk.Frame with an optional child widget. The title is used
+// as is, translate it with gtkext.Tr.
 func Frame(title string, w ...gtk.Widgetter) gtk.Widgetter {
 	fram := gtk.NewFrame(title)
 	if len(w) > 0 && w[0] != nil {
@@ -0,0 +1,17 @@
+// Command uicheck checks buildhelp accessor calls against the interface files
+// they load: ids must exist and match the accessor type.
+//
+// Usage:
+//
+//   uicheck ./...
+//   go vet -vettool=$(which uicheck) ./...
+//
+package main
+
+import (
+	"github.com/gtkool4/gtkelp/uicheck"
+
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() { singlechecker.Main(uicheck.Analyzer) }
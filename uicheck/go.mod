@@ -0,0 +1,19 @@
+module github.com/gtkool4/gtkelp/uicheck
+
+// Go 1.22 is required by golang.org/x/tools v0.26.0: older releases don't
+// build with current Go toolchains. The analyzer is a separate module to keep
+// this floor out of the library.
+go 1.22.0
+
+require (
+	github.com/gtkool4/gtkelp v0.0.0
+	golang.org/x/tools v0.26.0
+)
+
+require (
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/gtkool4/gtkelp => ../
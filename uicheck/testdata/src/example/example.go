@@ -0,0 +1,34 @@
+package example
+
+import (
+	_ "embed"
+
+	"github.com/gtkool4/gtkelp/buildhelp"
+)
+
+//go:embed main.ui
+var uiMain string
+
+func known() {
+	b := buildhelp.NewFromString(uiMain)
+	b.Button("ok")
+	b.Button("toggle")
+	b.Widget("label")
+	b.Button("custom")
+	b.Label("ok")       // want `buildhelp id "ok" is a GtkButton in main.ui, not \*gtk.Label`
+	b.Button("missing") // want `buildhelp id "missing" not found in main.ui`
+
+	p, _ := buildhelp.ParseString(uiMain)
+	p.Label("label")
+	buildhelp.NewFromString(uiMain).Label("toggle") // want `buildhelp id "toggle" is a GtkToggleButton in main.ui, not \*gtk.Label`
+
+	f := buildhelp.NewFromFile("other.ui")
+	f.Label("title")
+	f.Button("ok") // want `buildhelp id "ok" not found in other.ui`
+}
+
+func unknown(b *buildhelp.BuildHelp, id string) {
+	b.Label("title")
+	b.Label(id)
+	b.Button("nope") // want `buildhelp id "nope" not found in main.ui, other.ui`
+}
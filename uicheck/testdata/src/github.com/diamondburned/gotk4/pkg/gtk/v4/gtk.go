@@ -0,0 +1,10 @@
+// Package gtk is a stub of gotk4 types for analyzer tests.
+package gtk
+
+type Widget struct{}
+
+type Button struct{ Widget }
+
+type ToggleButton struct{ Button }
+
+type Label struct{ Widget }
@@ -0,0 +1,15 @@
+// Package buildhelp is a stub of the buildhelp API for analyzer tests.
+package buildhelp
+
+import "github.com/diamondburned/gotk4/pkg/gtk/v4"
+
+type BuildHelp struct{}
+
+func NewFromString(str string) *BuildHelp                 { return nil }
+func ParseString(str string) (*BuildHelp, error)          { return nil, nil }
+func NewFromFile(file string) *BuildHelp                  { return nil }
+func (b *BuildHelp) Button(id string) *gtk.Button         { return nil }
+func (b *BuildHelp) Label(id string) *gtk.Label           { return nil }
+func (b *BuildHelp) Widget(id string) *gtk.Widget         { return nil }
+func (b *BuildHelp) Bind(ptr interface{}) []error         { return nil }
+func (b *BuildHelp) ConnectSignals(h interface{}) []error { return nil }
@@ -0,0 +1,375 @@
+// Package uicheck defines an analyzer that checks buildhelp.BuildHelp accessor
+// calls against the interface files they load.
+//
+// Interface files are found from //go:embed strings and literal file names
+// given to buildhelp loaders:
+//
+//   //go:embed main.ui
+//   var uiMain string
+//
+//   b := buildhelp.NewFromString(uiMain)
+//   b.Button("cut") // Checked: the id exists in main.ui and is a GtkButton.
+//
+// When the builder origin is unknown, like a function parameter, ids are
+// checked against all the interface files of the package.
+package uicheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gtkool4/gtkelp/uixml"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// BuildHelpPath is the import path of the buildhelp package.
+var BuildHelpPath = "github.com/gtkool4/gtkelp/buildhelp"
+
+// Loaders lists buildhelp functions loading an interface from their first
+// argument: a string, or a file name for *File.
+var Loaders = []string{"NewFromString", "ParseString", "NewFromFile", "ParseFile"}
+
+// Diagnostics formating.
+var (
+	FmtErrNotFound = "buildhelp id %q not found in %s"      // Format: id, files
+	FmtErrBadType  = "buildhelp id %q is a %s in %s, not %s" // Format: id, class, file, type
+	FmtErrParse    = "buildhelp interface %s: %v"            // Format: file, error
+)
+
+// Analyzer checks buildhelp accessor calls against interface files.
+var Analyzer = &analysis.Analyzer{
+	Name:     "uicheck",
+	Doc:      "check buildhelp accessor ids and types against the embedded .ui files",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+// ui is a loaded interface file.
+type ui struct {
+	file  string // Base name, for messages.
+	root  *uixml.Node
+	class map[string]string // By id.
+}
+
+type checker struct {
+	pass     *analysis.Pass
+	files    map[string]*ui       // By path.
+	vars     map[types.Object]*ui // Embedded strings and builders.
+	all      []*ui                // For builders of unknown origin.
+	gotkPkgs map[string]*types.Package
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	c := &checker{
+		pass:     pass,
+		files:    map[string]*ui{},
+		vars:     map[types.Object]*ui{},
+		gotkPkgs: map[string]*types.Package{},
+	}
+	c.findGotk(pass.Pkg, map[*types.Package]bool{})
+	for _, f := range pass.Files {
+		c.findEmbeds(f)
+	}
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Rhs) == 1 && len(n.Lhs) > 0 {
+				c.assign(n.Lhs[0], n.Rhs[0])
+			}
+		case *ast.ValueSpec:
+			if len(n.Values) == 1 && len(n.Names) > 0 {
+				c.assign(n.Names[0], n.Values[0])
+			}
+		}
+	})
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		c.checkCall(n.(*ast.CallExpr))
+	})
+	return nil, nil
+}
+
+//
+//-----------------------------------------------------------------[ SOURCES ]--
+
+// findEmbeds loads the .ui files embedded in package variables.
+func (c *checker) findEmbeds(f *ast.File) {
+	dir := filepath.Dir(c.pass.Fset.File(f.Pos()).Name())
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			doc := vs.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			pattern := embedPattern(doc)
+			if pattern == "" || len(vs.Names) != 1 || !strings.HasSuffix(pattern, ".ui") {
+				continue
+			}
+			if u := c.load(filepath.Join(dir, pattern), vs.Pos()); u != nil {
+				c.vars[c.pass.TypesInfo.Defs[vs.Names[0]]] = u
+			}
+		}
+	}
+}
+
+// embedPattern returns the single file of a //go:embed directive.
+func embedPattern(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	for _, comment := range doc.List {
+		if !strings.HasPrefix(comment.Text, "//go:embed ") {
+			continue
+		}
+		if fields := strings.Fields(comment.Text[len("//go:embed "):]); len(fields) == 1 {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
+// load parses the interface file once.
+func (c *checker) load(path string, pos token.Pos) *ui {
+	if u, ok := c.files[path]; ok {
+		return u
+	}
+	root, e := uixml.ParseFile(path)
+	if e != nil {
+		c.pass.Reportf(pos, FmtErrParse, filepath.Base(path), e)
+		c.files[path] = nil
+		return nil
+	}
+	u := &ui{file: filepath.Base(path), root: root, class: map[string]string{}}
+	root.Walk(func(n, _ *uixml.Node) bool {
+		if id := n.Attr("id"); id != "" && (n.Name == "object" || n.Name == "menu") {
+			u.class[id] = n.Attr("class")
+			if n.Name == "menu" {
+				u.class[id] = "GMenu"
+			}
+		}
+		return n.Name != "template" // Template children are not in the builder.
+	})
+	c.files[path] = u
+	c.all = append(c.all, u)
+	return u
+}
+
+// assign records builders created from a known interface.
+func (c *checker) assign(lhs ast.Expr, rhs ast.Expr) {
+	id, ok := lhs.(*ast.Ident)
+	if !ok {
+		return
+	}
+	if u := c.origin(rhs); u != nil {
+		if obj := c.pass.TypesInfo.ObjectOf(id); obj != nil {
+			c.vars[obj] = u
+		}
+	}
+}
+
+// origin returns the interface loaded by the expression, or nil if unknown.
+func (c *checker) origin(expr ast.Expr) *ui {
+	switch expr := astutil.Unparen(expr).(type) {
+	case *ast.Ident:
+		return c.vars[c.pass.TypesInfo.ObjectOf(expr)]
+
+	case *ast.CallExpr:
+		fn := c.buildHelpFunc(expr.Fun)
+		if fn == nil || !contains(Loaders, fn.Name()) || len(expr.Args) == 0 {
+			return nil
+		}
+		if strings.HasSuffix(fn.Name(), "File") {
+			name, ok := c.constString(expr.Args[0])
+			if !ok {
+				return nil
+			}
+			dir := filepath.Dir(c.pass.Fset.File(expr.Pos()).Name())
+			return c.load(filepath.Join(dir, name), expr.Args[0].Pos())
+		}
+		return c.origin(expr.Args[0])
+	}
+	return nil
+}
+
+//
+//-------------------------------------------------------------------[ CHECK ]--
+
+// checkCall checks accessor calls: b.Button("id").
+func (c *checker) checkCall(call *ast.CallExpr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 1 {
+		return
+	}
+	method, ok := c.pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || !isAccessor(method) {
+		return
+	}
+	id, ok := c.constString(call.Args[0])
+	if !ok {
+		return
+	}
+
+	candidates := c.all
+	if u := c.origin(sel.X); u != nil {
+		candidates = []*ui{u}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	result := method.Type().(*types.Signature).Results().At(0).Type()
+	var found []string
+	for _, u := range candidates {
+		class, ok := u.class[id]
+		if !ok {
+			continue
+		}
+		if c.isA(class, result) {
+			return
+		}
+		found = append(found, class+" in "+u.file)
+	}
+
+	if len(found) == 0 {
+		c.pass.Reportf(call.Args[0].Pos(), FmtErrNotFound, id, fileList(candidates))
+		return
+	}
+	sort.Strings(found)
+	class := strings.SplitN(found[0], " in ", 2)
+	c.pass.Reportf(call.Args[0].Pos(), FmtErrBadType, id, class[0], class[1], types.TypeString(result, shortQualifier))
+}
+
+// isAccessor returns true for methods of BuildHelp returning a typed object.
+func isAccessor(fn *types.Func) bool {
+	sig := fn.Type().(*types.Signature)
+	if sig.Recv() == nil || sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+		return false
+	}
+	recv, ok := deref(sig.Recv().Type()).(*types.Named)
+	if !ok || recv.Obj().Name() != "BuildHelp" || recv.Obj().Pkg() == nil || recv.Obj().Pkg().Path() != BuildHelpPath {
+		return false
+	}
+	basic, ok := sig.Params().At(0).Type().(*types.Basic)
+	_, named := deref(sig.Results().At(0).Type()).(*types.Named)
+	return ok && basic.Kind() == types.String && named
+}
+
+// isA returns true if the gotk4 type of the class is or embeds the result type.
+// Classes without known gotk4 type are accepted.
+func (c *checker) isA(class string, result types.Type) bool {
+	pkgName, name := uixml.GoType(class)
+	if class == "GMenu" {
+		pkgName, name = "gio", "Menu"
+	}
+	pkg := c.gotkPkgs[pkgName]
+	if pkg == nil {
+		return true
+	}
+	obj := pkg.Scope().Lookup(name)
+	if obj == nil {
+		return true
+	}
+	return embeds(obj.Type(), deref(result), map[types.Type]bool{})
+}
+
+// embeds returns true if t is target or embeds it, at any depth.
+func embeds(t, target types.Type, seen map[types.Type]bool) bool {
+	if types.Identical(t, target) {
+		return true
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		if f := st.Field(i); f.Anonymous() && embeds(deref(f.Type()), target, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// findGotk indexes the gotk4 packages imported, by package name: gtk, gio...
+func (c *checker) findGotk(pkg *types.Package, seen map[*types.Package]bool) {
+	if seen[pkg] {
+		return
+	}
+	seen[pkg] = true
+	if strings.Contains(pkg.Path(), "gotk4/pkg/") {
+		c.gotkPkgs[pkg.Name()] = pkg
+	}
+	for _, imp := range pkg.Imports() {
+		c.findGotk(imp, seen)
+	}
+}
+
+// buildHelpFunc returns the buildhelp package function called, or nil.
+func (c *checker) buildHelpFunc(fun ast.Expr) *types.Func {
+	sel, ok := fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	fn, ok := c.pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != BuildHelpPath || fn.Type().(*types.Signature).Recv() != nil {
+		return nil
+	}
+	return fn
+}
+
+// constString returns the value of a constant string expression.
+func (c *checker) constString(expr ast.Expr) (string, bool) {
+	tv, ok := c.pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+//
+//-----------------------------------------------------------------[ HELPERS ]--
+
+func deref(t types.Type) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return t
+}
+
+func shortQualifier(pkg *types.Package) string { return pkg.Name() }
+
+func fileList(list []*ui) string {
+	names := make([]string, len(list))
+	for i, u := range list {
+		names[i] = u.file
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
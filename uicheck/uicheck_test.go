@@ -0,0 +1,13 @@
+package uicheck_test
+
+import (
+	"testing"
+
+	"github.com/gtkool4/gtkelp/uicheck"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), uicheck.Analyzer, "example")
+}
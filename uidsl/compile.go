@@ -0,0 +1,181 @@
+package uidsl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+// FmtErrXML formats errors of xml statements.
+var FmtErrXML = "xml: %s" // Format: error
+
+//
+//-----------------------------------------------------------------[ COMPILE ]--
+
+// ClassName returns the class of a name in the syntax: Box is GtkBox,
+// Adw.HeaderBar is AdwHeaderBar and $MyWidget is MyWidget.
+func ClassName(name string) string {
+	switch {
+	case strings.HasPrefix(name, "$"):
+		return name[1:]
+	case strings.Contains(name, "."):
+		return strings.ReplaceAll(name, ".", "")
+	}
+	return "Gtk" + name
+}
+
+func compileBody(parent *uixml.Node, body []*stmt) error {
+	for _, st := range body {
+		if st.kind == "domain" {
+			parent.SetAttr("domain", st.value.text)
+			continue
+		}
+		if st.kind == "xml" {
+			list, e := st.fragment()
+			if e != nil {
+				return e
+			}
+			parent.Append(list...)
+			continue
+		}
+		n, e := st.compile()
+		if e != nil {
+			return e
+		}
+		if st.kind == "object" && parent.Name != "interface" { // Child object.
+			child := &uixml.Node{Name: "child", Pos: st.pos}
+			if st.child != nil {
+				child.SetAttr(st.child[0], st.child[1])
+			}
+			n = child.Append(n)
+		}
+		parent.Append(n)
+	}
+	return nil
+}
+
+// compile converts the statement to its element, and the file to the
+// interface.
+func (st *stmt) compile() (*uixml.Node, error) {
+	n := &uixml.Node{Pos: st.pos}
+	switch st.kind {
+	case "file":
+		n.Name = "interface"
+		if len(st.body) > 0 {
+			n.Pos = st.body[0].pos
+		}
+		if e := compileBody(n, st.body); e != nil {
+			return nil, e
+		}
+
+	case "requires":
+		n.Name = "requires"
+		n.SetAttr("lib", st.name)
+		n.SetAttr("version", st.id)
+
+	case "object":
+		n.Name = "object"
+		n.SetAttr("class", ClassName(st.name))
+		if st.id != "" {
+			n.SetAttr("id", st.id)
+		}
+		if e := compileBody(n, st.body); e != nil {
+			return nil, e
+		}
+
+	case "template":
+		n.Name = "template"
+		n.SetAttr("class", strings.TrimPrefix(st.name, "$"))
+		n.SetAttr("parent", ClassName(st.id))
+		if e := compileBody(n, st.body); e != nil {
+			return nil, e
+		}
+
+	case "menu", "section", "submenu", "item":
+		n.Name = st.kind
+		if st.id != "" {
+			n.SetAttr("id", st.id)
+		}
+		if e := compileBody(n, st.body); e != nil {
+			return nil, e
+		}
+
+	case "property", "attribute":
+		n.Name = st.kind
+		n.SetAttr("name", st.name)
+		v := st.value
+		switch {
+		case v.object != nil:
+			obj, e := v.object.compile()
+			if e != nil {
+				return nil, e
+			}
+			n.Append(obj)
+
+		case v.bind != nil:
+			n.SetAttr("bind-source", v.bind[0])
+			n.SetAttr("bind-property", v.bind[1])
+			if len(v.bind) > 2 {
+				n.SetAttr("bind-flags", strings.Join(v.bind[2:], "|"))
+			}
+
+		default:
+			n.Text = v.text
+			if v.i18n {
+				n.SetAttr("translatable", "yes")
+			}
+			if v.context != "" {
+				n.SetAttr("context", v.context)
+			}
+		}
+
+	case "signal":
+		n.Name = "signal"
+		n.SetAttr("name", st.name)
+		n.SetAttr("handler", st.words[0])
+		for _, flag := range st.words[1:] {
+			n.SetAttr(flag, "yes")
+		}
+
+	case "styles":
+		n.Name = "style"
+		for _, name := range st.words {
+			class := uixml.New("class", "name", name)
+			class.Pos = st.pos
+			n.Append(class)
+		}
+
+	case "layout":
+		n.Name = "layout"
+		if e := compileBody(n, st.body); e != nil {
+			return nil, e
+		}
+	}
+	return n, nil
+}
+
+// fragment parses the elements of the xml statement, with positions in the
+// source.
+func (st *stmt) fragment() ([]*uixml.Node, error) {
+	root, e := uixml.ParseString("<xml>"+st.src.text+"</xml>", st.pos.File)
+	shift := func(pos uixml.Pos) uixml.Pos {
+		if !st.src.raw {
+			return st.src.pos // Escapes move columns.
+		}
+		if pos.Line == 1 {
+			pos.Col += st.src.pos.Col - len("<xml>")
+		}
+		pos.Line += st.src.pos.Line - 1
+		return pos
+	}
+	if e != nil {
+		se := e.(*uixml.SyntaxError)
+		return nil, &uixml.SyntaxError{Pos: shift(se.Pos), Msg: fmt.Sprintf(FmtErrXML, se.Msg)}
+	}
+	root.Walk(func(n, _ *uixml.Node) bool {
+		n.Pos = shift(n.Pos)
+		return true
+	})
+	return root.Children, nil
+}
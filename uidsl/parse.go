@@ -0,0 +1,505 @@
+package uidsl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+// Errors formating.
+var (
+	FmtErrExpected = "expected %s, found %s" // Format: expected, found
+	FmtErrChar     = "unexpected character %q"
+	FmtErrFlag     = "unknown signal flag %s" // Format: flag
+	TxtErrString   = "string not terminated"
+)
+
+//
+//-------------------------------------------------------------------[ LEXER ]--
+
+// Token kinds.
+const (
+	tokEOF = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokPunct
+	tokComment
+)
+
+// token is a lexical token of the source.
+type token struct {
+	kind  int
+	text  string    // Unquoted for strings, without // for comments.
+	raw   bool      // String between backquotes.
+	pos   uixml.Pos // Position of the first character.
+	blank bool      // Preceded by a blank line.
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of file"
+	case tokString:
+		return "string " + strconv.Quote(t.text)
+	}
+	return strconv.Quote(t.text)
+}
+
+func isIdent(c byte, first bool) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_', c == '$', c >= 0x80:
+		return true
+	case first:
+		return false
+	}
+	return c >= '0' && c <= '9' || c == '-' || c == '.'
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// lex splits the source in tokens, ending with tokEOF.
+func lex(data []byte, file string) (list []token, e error) {
+	line, col, newlines := 1, 1, 0
+	for i := 0; ; {
+		for i < len(data) && strings.IndexByte(" \t\r\n", data[i]) >= 0 { // Blanks.
+			if data[i] == '\n' {
+				line, col, newlines = line+1, 1, newlines+1
+			} else {
+				col++
+			}
+			i++
+		}
+		tok := token{pos: uixml.Pos{File: file, Line: line, Col: col}, blank: newlines > 1 && len(list) > 0}
+		if i == len(data) {
+			return append(list, tok), nil
+		}
+
+		start := i
+		c := data[i]
+		switch {
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			tok.kind = tokComment
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			tok.text = strings.TrimRight(string(data[start+2:i]), " \t\r")
+
+		case isIdent(c, true):
+			tok.kind = tokIdent
+			for i < len(data) && (isIdent(data[i], false) || data[i] == ':' && i+2 < len(data) && data[i+1] == ':' && isIdent(data[i+2], true)) {
+				if data[i] == ':' { // Detailed signal: notify::label.
+					i++
+				}
+				i++
+			}
+			tok.text = string(data[start:i])
+
+		case isDigit(c) || (c == '-' || c == '+') && i+1 < len(data) && isDigit(data[i+1]):
+			tok.kind = tokNumber
+			for i++; i < len(data) && (isDigit(data[i]) || data[i] == '.'); i++ {
+			}
+			tok.text = string(data[start:i])
+
+		case c == '"':
+			tok.kind = tokString
+			for i++; i < len(data) && data[i] != '"' && data[i] != '\n'; i++ {
+				if data[i] == '\\' && i+1 < len(data) {
+					i++
+				}
+			}
+			if i == len(data) || data[i] != '"' {
+				return nil, &uixml.SyntaxError{Pos: tok.pos, Msg: TxtErrString}
+			}
+			i++
+			if tok.text, e = strconv.Unquote(string(data[start:i])); e != nil {
+				return nil, &uixml.SyntaxError{Pos: tok.pos, Msg: e.Error()}
+			}
+
+		case c == '`':
+			tok.kind, tok.raw = tokString, true
+			end := strings.IndexByte(string(data[i+1:]), '`')
+			if end < 0 {
+				return nil, &uixml.SyntaxError{Pos: tok.pos, Msg: TxtErrString}
+			}
+			i += end + 2
+			tok.text = string(data[start+1 : i-1])
+
+		case c == '=' && i+1 < len(data) && data[i+1] == '>':
+			tok.kind, tok.text = tokPunct, "=>"
+			i += 2
+
+		case strings.IndexByte("{}[]();:,", c) >= 0:
+			tok.kind, tok.text = tokPunct, string(c)
+			i++
+
+		default:
+			return nil, &uixml.SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf(FmtErrChar, c)}
+		}
+
+		for _, c := range data[start:i] { // Raw strings can span lines.
+			if c == '\n' {
+				line, col = line+1, 0
+			}
+			col++
+		}
+		newlines = 0
+		list = append(list, tok)
+	}
+}
+
+//
+//--------------------------------------------------------------------[ TREE ]--
+
+// stmt is a statement of the syntax tree. The file is a statement of kind
+// file.
+type stmt struct {
+	kind     string    // file, requires, domain, object, template, menu, section, submenu, item, property, attribute, signal, styles, layout, xml.
+	pos      uixml.Pos // Position of the first token.
+	comments []string  // Comment lines before the statement.
+	comment  string    // Comment at the end of the line.
+	blank    bool      // Preceded by a blank line.
+
+	name  string   // Class, lib, property, attribute or signal name.
+	id    string   // Object id, template parent or lib version.
+	child []string // Child attributes of the object: type or internal-child, and value.
+	value value    // Property, attribute or domain value.
+	words []string // Signal handler and flags, or style classes.
+	body  []*stmt  // Statements of blocks.
+	end   []string // Comment lines before the end of the block.
+	src   token    // Xml string token, for positions of elements.
+}
+
+// value is a property or attribute value.
+type value struct {
+	text    string
+	context string   // Translation context.
+	i18n    bool     // Translatable.
+	quoted  bool     // Written as a string.
+	object  *stmt    // Object value, with a block.
+	bind    []string // Binding source, property and flags.
+}
+
+//
+//------------------------------------------------------------------[ PARSER ]--
+
+type parser struct {
+	toks []token
+	i    int
+}
+
+func parse(data []byte, file string) (*stmt, error) {
+	toks, e := lex(data, file)
+	if e != nil {
+		return nil, e
+	}
+	p := &parser{toks: toks}
+	f := &stmt{kind: "file", pos: uixml.Pos{File: file, Line: 1, Col: 1}}
+	f.body, f.end, e = p.stmts("file")
+	return f, e
+}
+
+// tok returns the current token, after comments.
+func (p *parser) tok() token {
+	for p.toks[p.i].kind == tokComment {
+		p.i++
+	}
+	return p.toks[p.i]
+}
+
+// ahead returns the token after the current one, after comments.
+func (p *parser) ahead() token { return p.peek(1) }
+
+// peek returns the nth token after the current one, skipping comments.
+func (p *parser) peek(n int) token {
+	for i := p.i; i < len(p.toks); i++ {
+		if p.toks[i].kind == tokComment {
+			continue
+		}
+		if n == 0 {
+			return p.toks[i]
+		}
+		n--
+	}
+	return p.toks[len(p.toks)-1]
+}
+
+func (p *parser) next() token {
+	tok := p.tok()
+	if tok.kind != tokEOF {
+		p.i++
+	}
+	return tok
+}
+
+func (p *parser) errorf(tok token, expected string) error {
+	return &uixml.SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf(FmtErrExpected, expected, tok)}
+}
+
+// expect reads a token of the kind, with the text if not empty.
+func (p *parser) expect(kind int, text, expected string) (token, error) {
+	tok := p.tok()
+	if tok.kind != kind || text != "" && tok.text != text {
+		return tok, p.errorf(tok, expected)
+	}
+	return p.next(), nil
+}
+
+func (p *parser) punct(text string) error {
+	_, e := p.expect(tokPunct, text, strconv.Quote(text))
+	return e
+}
+
+func (p *parser) is(text string) bool {
+	tok := p.tok()
+	return tok.kind == tokPunct && tok.text == text
+}
+
+// word reads an identifier, number or string.
+func (p *parser) word(expected string) (string, error) {
+	tok := p.tok()
+	if tok.kind != tokIdent && tok.kind != tokNumber && tok.kind != tokString {
+		return "", p.errorf(tok, expected)
+	}
+	return p.next().text, nil
+}
+
+// stmts reads statements until the end of the block or file, with the comments
+// before the end.
+func (p *parser) stmts(ctx string) (list []*stmt, end []string, e error) {
+	for {
+		var comments []string
+		blank := p.toks[p.i].blank
+		for p.toks[p.i].kind == tokComment {
+			comments = append(comments, p.toks[p.i].text)
+			p.i++
+		}
+		tok := p.tok()
+		switch {
+		case tok.kind == tokEOF && ctx == "file", p.is("}") && ctx != "file":
+			return list, comments, nil
+		case tok.kind == tokEOF:
+			return nil, nil, p.errorf(tok, `"}"`)
+		}
+		st, e := p.stmt(ctx)
+		if e != nil {
+			return nil, nil, e
+		}
+		st.comments, st.blank = comments, blank
+		last := p.toks[p.i-1]
+		if next := p.toks[p.i]; next.kind == tokComment && next.pos.Line == last.pos.Line+strings.Count(last.text, "\n") {
+			st.comment = next.text
+			p.i++
+		}
+		list = append(list, st)
+	}
+}
+
+// stmt reads a statement allowed in the context: file, object, menu or layout.
+func (p *parser) stmt(ctx string) (*stmt, error) {
+	tok, ahead := p.tok(), p.ahead()
+	st := &stmt{pos: tok.pos}
+	var e error
+	switch {
+	case tok.kind == tokIdent && ahead.kind == tokPunct && ahead.text == ":" && ctx != "file":
+		st.kind, st.name = "property", p.next().text
+		if ctx == "menu" {
+			st.kind = "attribute"
+		}
+		p.next()
+		if st.value, e = p.value(ctx == "object"); e != nil || st.value.object != nil {
+			return st, e
+		}
+		return st, p.punct(";")
+
+	case tok.kind == tokIdent && ahead.kind == tokPunct && ahead.text == "=>" && ctx == "object":
+		st.kind, st.name = "signal", p.next().text
+		p.next()
+		for p.tok().kind == tokIdent {
+			st.words = append(st.words, p.next().text)
+		}
+		if len(st.words) == 0 {
+			return nil, p.errorf(p.tok(), "handler")
+		}
+		for _, flag := range st.words[1:] {
+			if flag != "swapped" && flag != "after" {
+				return nil, &uixml.SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf(FmtErrFlag, flag)}
+			}
+		}
+		return st, p.punct(";")
+
+	case ctx == "layout":
+		return nil, p.errorf(tok, "layout property")
+
+	case tok.kind == tokIdent && tok.text == "xml" && ahead.kind == tokString:
+		p.next()
+		st.kind, st.src = "xml", p.next()
+		return st, p.punct(";")
+
+	case ctx == "menu":
+		if tok.kind != tokIdent || tok.text != "section" && tok.text != "submenu" && tok.text != "item" {
+			return nil, p.errorf(tok, "menu attribute, section, submenu or item")
+		}
+		st.kind = p.next().text
+		return st, p.block(st, "menu", true)
+
+	case tok.kind == tokIdent && tok.text == "requires" && ctx == "file":
+		p.next()
+		if st.name, e = p.word("library"); e == nil {
+			st.id, e = p.word("version")
+		}
+		st.kind = "requires"
+		if e != nil {
+			return nil, e
+		}
+		return st, p.punct(";")
+
+	case tok.kind == tokIdent && tok.text == "domain" && ctx == "file":
+		p.next()
+		st.kind = "domain"
+		if st.value.text, e = p.word("domain"); e != nil {
+			return nil, e
+		}
+		return st, p.punct(";")
+
+	case tok.kind == tokIdent && tok.text == "template" && ctx == "file":
+		p.next()
+		st.kind = "template"
+		if st.name, e = p.word("template class"); e == nil {
+			if e = p.punct(":"); e == nil {
+				st.id, e = p.word("parent class")
+			}
+		}
+		if e != nil {
+			return nil, e
+		}
+		return st, p.block(st, "object", false)
+
+	case tok.kind == tokIdent && tok.text == "menu" && ctx == "file":
+		st.kind = p.next().text
+		return st, p.block(st, "menu", true)
+
+	case tok.kind == tokIdent && tok.text == "styles" && ahead.kind == tokPunct && ahead.text == "[" && ctx == "object":
+		p.next()
+		p.next()
+		st.kind = "styles"
+		for !p.is("]") {
+			class, e := p.word("style class")
+			if e != nil {
+				return nil, e
+			}
+			st.words = append(st.words, class)
+			if !p.is("]") {
+				if e := p.punct(","); e != nil {
+					return nil, e
+				}
+			}
+		}
+		p.next()
+		return st, p.punct(";")
+
+	case tok.kind == tokIdent && tok.text == "layout" && ahead.kind == tokPunct && ahead.text == "{" && ctx == "object":
+		p.next()
+		st.kind = "layout"
+		return st, p.block(st, "layout", false)
+
+	case p.is("[") && ctx == "object":
+		p.next()
+		typ, e := p.word("child type")
+		if e != nil {
+			return nil, e
+		}
+		st.child = []string{"type", typ}
+		if typ == "internal-child" {
+			name, e := p.word("internal child name")
+			if e != nil {
+				return nil, e
+			}
+			st.child = []string{"internal-child", name}
+		}
+		if e := p.punct("]"); e != nil {
+			return nil, e
+		}
+		return st, p.object(st)
+
+	case tok.kind == tokIdent:
+		return st, p.object(st)
+	}
+	return nil, p.errorf(tok, "statement")
+}
+
+// object reads Class id { ... } in the statement.
+func (p *parser) object(st *stmt) (e error) {
+	st.kind = "object"
+	if st.name, e = p.word("class"); e != nil {
+		return e
+	}
+	return p.block(st, "object", true)
+}
+
+// block reads the optional id, and the block of statements.
+func (p *parser) block(st *stmt, ctx string, id bool) (e error) {
+	if tok := p.tok(); id && (tok.kind == tokIdent || tok.kind == tokString) {
+		st.id = p.next().text
+	}
+	if !p.is("{") && st.kind == "object" {
+		return p.errorf(p.tok(), `"{" of object or ":" of property`)
+	}
+	if e := p.punct("{"); e != nil {
+		return e
+	}
+	if st.body, st.end, e = p.stmts(ctx); e != nil {
+		return e
+	}
+	return p.punct("}")
+}
+
+// value reads a property or attribute value.
+func (p *parser) value(object bool) (v value, e error) {
+	tok, ahead := p.tok(), p.ahead()
+	switch {
+	case tok.kind == tokIdent && (tok.text == "_" || tok.text == "C_") && ahead.kind == tokPunct && ahead.text == "(":
+		p.next()
+		p.next()
+		v.i18n = true
+		if tok.text == "C_" {
+			if v.context, e = p.expectString(); e != nil {
+				return v, e
+			}
+			if e = p.punct(","); e != nil {
+				return v, e
+			}
+		}
+		if v.text, e = p.expectString(); e != nil {
+			return v, e
+		}
+		return v, p.punct(")")
+
+	case tok.kind == tokIdent && tok.text == "bind" && ahead.kind == tokIdent && object:
+		p.next()
+		src := p.next()
+		dot := strings.LastIndexByte(src.text, '.')
+		if dot <= 0 || dot == len(src.text)-1 {
+			return v, p.errorf(src, "source.property")
+		}
+		v.bind = []string{src.text[:dot], src.text[dot+1:]}
+		for p.tok().kind == tokIdent {
+			v.bind = append(v.bind, p.next().text)
+		}
+		return v, nil
+
+	case tok.kind == tokIdent && object && (ahead.kind == tokPunct && ahead.text == "{" || (ahead.kind == tokIdent || ahead.kind == tokString) && p.peek(2).kind == tokPunct && p.peek(2).text == "{"):
+		v.object = &stmt{pos: tok.pos}
+		return v, p.object(v.object)
+	}
+	v.quoted = tok.kind == tokString
+	v.text, e = p.word("value")
+	return v, e
+}
+
+func (p *parser) expectString() (string, error) {
+	tok, e := p.expect(tokString, "", "string")
+	return tok.text, e
+}
@@ -0,0 +1,99 @@
+// Package uidsl compiles a concise text syntax to gtk.Builder interfaces.
+//
+// Objects are written as Class id { ... } with properties, signals and
+// children inside:
+//
+//   requires gtk 4.0;
+//
+//   Box mainbox {
+//     orientation: vertical;
+//     styles ["card"];
+//
+//     HeaderBar menubar {
+//       [start] Button cut {
+//         icon-name: "edit-cut";
+//         tooltip-text: _("Cut");
+//         clicked => Cut;
+//       }
+//     }
+//   }
+//
+// Statements of the file:
+//
+//   requires gtk 4.0;                <requires lib="gtk" version="4.0"/>
+//   domain "app";                    Translation domain of the interface.
+//   Class id { ... }                 Object, the id is optional.
+//   template MyWidget : Box { ... }  Composite template.
+//   menu id { ... }                  Menu, with section, submenu and item blocks
+//                                    of attributes: label: _("_Quit");
+//   xml `<object ...>`;              Elements given as XML, also in blocks.
+//
+// Statements of objects:
+//
+//   name: value;                     Property.
+//   name: Class id { ... }           Property holding an object.
+//   name: bind source.prop flags;    Property binding, flags like sync-create.
+//   name => Handler swapped after;   Signal, with optional flags.
+//   styles ["card", "view"];         CSS classes.
+//   layout { column: 1; }            Layout properties of the child.
+//   [type] Class id { ... }          Child, with an optional child type, or
+//                                    [internal-child name].
+//
+// Values are words (vertical, 6, -0.5), "quoted strings", `raw strings`, or
+// translatable strings: _("text") and C_("context", "text").
+//
+// Gtk classes are written without prefix: Box is GtkBox. Other classes are
+// written with their namespace, like Adw.HeaderBar, or as is with a $ prefix,
+// like $MyWidget.
+//
+// Comments start with // and are kept by Format.
+//
+package uidsl
+
+import (
+	"bytes"
+
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+// Ext is the extension of interface files in this syntax, registered in
+// uixml.Parsers.
+const Ext = ".uidsl"
+
+func init() { uixml.Parsers[Ext] = Parse }
+
+// Parse compiles the interface source to the gtk.Builder XML tree. Nodes keep
+// their position in the source, and errors are *uixml.SyntaxError.
+func Parse(data []byte, file string) (*uixml.Node, error) {
+	f, e := parse(data, file)
+	if e != nil {
+		return nil, e
+	}
+	return f.compile()
+}
+
+// ParseString compiles the interface source string, see Parse.
+func ParseString(str, file string) (*uixml.Node, error) { return Parse([]byte(str), file) }
+
+// Format returns the source formatted: indented by 2 spaces, one statement per
+// line, with comments kept.
+func Format(data []byte, file string) ([]byte, error) {
+	f, e := parse(data, file)
+	if e != nil {
+		return nil, e
+	}
+	if _, e := f.compile(); e != nil { // Check embedded xml.
+		return nil, e
+	}
+	var buf bytes.Buffer
+	f.write(&buf, 0)
+	return buf.Bytes(), nil
+}
+
+// Write converts the gtk.Builder interface to the syntax. Elements that can't
+// be written with statements are kept as xml strings.
+func Write(root *uixml.Node) []byte {
+	var buf bytes.Buffer
+	decompile(root).write(&buf, 0)
+	return buf.Bytes()
+}
@@ -0,0 +1,92 @@
+package uidsl_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gtkool4/gtkelp/uidsl"
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+//
+//-------------------------------------------------------------------[ TESTS ]--
+
+func TestParse(t *testing.T) {
+	src, _ := os.ReadFile("testdata/example.uidsl")
+	root, e := uidsl.Parse(src, "example.uidsl")
+	if e != nil {
+		t.Fatal("parse error:", e)
+	}
+	expected, _ := os.ReadFile("testdata/example.ui.golden")
+	testExpectedString(t, string(expected), root.String())
+
+	testExpectedString(t, "example.uidsl:8:5", root.Lookup("cut").Pos.String())
+	testExpectedString(t, "example.uidsl:44:6", root.Lookup("raw").Pos.String())
+
+	root, e = uixml.ParseFile("testdata/example.uidsl") // Registered extension.
+	if e != nil || root.Lookup("mainbox") == nil {
+		t.Error("uixml should parse files with the extension", uidsl.Ext, e)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	src, _ := os.ReadFile("testdata/example.uidsl")
+	have, e := uidsl.Format(src, "example.uidsl")
+	if e != nil {
+		t.Fatal("format error:", e)
+	}
+	testExpectedString(t, string(src), string(have))
+
+	have, _ = uidsl.Format([]byte("Box   main{orientation:vertical;\n\n\n  [end]Label{label:\"x\";} // End.\n}\n// Last."), "")
+	testExpectedString(t, "Box main {\n  orientation: vertical;\n\n  [end] Label {\n    label: \"x\";\n  } // End.\n}\n// Last.\n", string(have))
+}
+
+func TestWrite(t *testing.T) {
+	for _, file := range []string{"../uixml/testdata/basic.ui", "../cmd/uiconv/testdata/main.ui", "../cmd/uigen/testdata/misc.ui"} {
+		root, e := uixml.ParseFile(file)
+		if e != nil {
+			t.Fatal("parse error:", e)
+		}
+		src := uidsl.Write(root)
+		back, e := uidsl.Parse(src, "")
+		if e != nil {
+			t.Fatalf("parse %s written: %v\n%s", file, e, src)
+		}
+		testExpectedString(t, root.String(), back.String())
+	}
+}
+
+func TestSyntaxError(t *testing.T) {
+	for src, expected := range map[string]string{
+		"Box {\n  orientation vertical;\n}":  `f.uidsl:2:23: expected "{" of object or ":" of property, found ";"`,
+		"Box {\n  label: \"x;\n}":            "f.uidsl:2:10: " + uidsl.TxtErrString,
+		"Box {\n  clicked => Quit later;\n}": "f.uidsl:2:3: unknown signal flag later",
+		"Box {":                              `f.uidsl:1:6: expected "}", found end of file`,
+		"Box { label: _(x); }":               `f.uidsl:1:16: expected string, found "x"`,
+		"Box { # }":                          `f.uidsl:1:7: unexpected character '#'`,
+		"xml `<a>\n</b>`;":                   "f.uidsl:2:1: xml: element <a> closed by </b>",
+		"menu m { Label {} }":                `f.uidsl:1:10: expected menu attribute, section, submenu or item, found "Label"`,
+	} {
+		_, e := uidsl.ParseString(src, "f.uidsl")
+		var se *uixml.SyntaxError
+		if !errors.As(e, &se) {
+			t.Errorf("parse %q should return a *SyntaxError, not %v", src, e)
+			continue
+		}
+		if !strings.HasPrefix(e.Error(), expected) {
+			t.Errorf("parse %q: error should start with %q but we have %q", src, expected, e)
+		}
+	}
+}
+
+//
+//-----------------------------------------------------------------[ HELPERS ]--
+
+func testExpectedString(t *testing.T, expected, have string) {
+	t.Helper()
+	if expected != have {
+		t.Errorf("text should be \"%s\" but we have \"%s\"", expected, have)
+	}
+}
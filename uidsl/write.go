@@ -0,0 +1,397 @@
+package uidsl
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+// Indent is the indentation of blocks.
+var Indent = "  "
+
+var (
+	reWord   = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_.-]*|[-+]?[0-9][0-9.]*)$`)
+	reName   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	reSignal = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*(::[A-Za-z_][A-Za-z0-9_-]*)?$`)
+	reClass  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+//
+//-------------------------------------------------------------------[ WRITE ]--
+
+// write writes the statement and its block at the depth.
+func (st *stmt) write(buf *bytes.Buffer, depth int) {
+	if st.kind == "file" {
+		for i, child := range st.body {
+			if i > 0 && (child.blank || child.block() || st.body[i-1].block()) {
+				buf.WriteString("\n")
+			}
+			child.write(buf, 0)
+		}
+		writeComments(buf, st.end, "")
+		return
+	}
+
+	indent := strings.Repeat(Indent, depth)
+	writeComments(buf, st.comments, indent)
+	buf.WriteString(indent)
+	st.writeLine(buf, depth)
+	if st.comment != "" {
+		buf.WriteString(" //" + st.comment)
+	}
+	buf.WriteString("\n")
+}
+
+// writeLine writes the statement without indent, comments and newline.
+func (st *stmt) writeLine(buf *bytes.Buffer, depth int) {
+	switch st.kind {
+	case "requires":
+		buf.WriteString("requires " + quoteWord(st.name) + " " + quoteWord(st.id) + ";")
+
+	case "domain":
+		buf.WriteString("domain " + strconv.Quote(st.value.text) + ";")
+
+	case "object":
+		if st.child != nil {
+			label := quoteWord(st.child[1])
+			if st.child[0] == "internal-child" {
+				label = "internal-child " + label
+			}
+			buf.WriteString("[" + label + "] ")
+		}
+		buf.WriteString(st.name + st.idWord())
+		st.writeBlock(buf, depth)
+
+	case "template":
+		buf.WriteString("template " + st.name + " : " + st.id)
+		st.writeBlock(buf, depth)
+
+	case "menu", "section", "submenu", "item":
+		buf.WriteString(st.kind + st.idWord())
+		st.writeBlock(buf, depth)
+
+	case "layout":
+		buf.WriteString("layout")
+		st.writeBlock(buf, depth)
+
+	case "property", "attribute":
+		buf.WriteString(st.name + ": ")
+		v := st.value
+		switch {
+		case v.object != nil:
+			buf.WriteString(v.object.name + v.object.idWord())
+			v.object.writeBlock(buf, depth)
+			return
+		case v.bind != nil:
+			buf.WriteString("bind " + strings.Join(v.bind[:2], ".") + strings.Join(append([]string{""}, v.bind[2:]...), " "))
+		case v.context != "":
+			buf.WriteString("C_(" + strconv.Quote(v.context) + ", " + strconv.Quote(v.text) + ")")
+		case v.i18n:
+			buf.WriteString("_(" + strconv.Quote(v.text) + ")")
+		case v.quoted:
+			buf.WriteString(strconv.Quote(v.text))
+		default:
+			buf.WriteString(quoteWord(v.text))
+		}
+		buf.WriteString(";")
+
+	case "signal":
+		buf.WriteString(st.name + " => " + strings.Join(st.words, " ") + ";")
+
+	case "styles":
+		var classes []string
+		for _, class := range st.words {
+			classes = append(classes, strconv.Quote(class))
+		}
+		buf.WriteString("styles [" + strings.Join(classes, ", ") + "];")
+
+	case "xml":
+		str := st.src.text
+		if strings.Contains(str, "`") {
+			buf.WriteString("xml " + strconv.Quote(str) + ";")
+		} else {
+			buf.WriteString("xml `" + str + "`;")
+		}
+	}
+}
+
+// idWord returns the id after a space, or an empty string.
+func (st *stmt) idWord() string {
+	if st.id == "" {
+		return ""
+	}
+	return " " + quoteWord(st.id)
+}
+
+// writeBlock writes the block of statements.
+func (st *stmt) writeBlock(buf *bytes.Buffer, depth int) {
+	if len(st.body) == 0 && len(st.end) == 0 {
+		buf.WriteString(" {}")
+		return
+	}
+	buf.WriteString(" {\n")
+	for i, child := range st.body {
+		if i > 0 && child.blank {
+			buf.WriteString("\n")
+		}
+		child.write(buf, depth+1)
+	}
+	indent := strings.Repeat(Indent, depth)
+	writeComments(buf, st.end, indent+Indent)
+	buf.WriteString(indent + "}")
+}
+
+// block returns true if the statement has a block.
+func (st *stmt) block() bool {
+	switch st.kind {
+	case "object", "template", "menu":
+		return true
+	}
+	return false
+}
+
+func writeComments(buf *bytes.Buffer, comments []string, indent string) {
+	for _, comment := range comments {
+		buf.WriteString(indent + "//" + comment + "\n")
+	}
+}
+
+// quoteWord returns the text as is if it can be read as a word, else quoted.
+func quoteWord(text string) string {
+	if reWord.MatchString(text) {
+		return text
+	}
+	return strconv.Quote(text)
+}
+
+//
+//---------------------------------------------------------------[ DECOMPILE ]--
+
+// decompile converts the interface to the syntax tree of the file.
+func decompile(root *uixml.Node) *stmt {
+	f := &stmt{kind: "file"}
+	if root.Name != "interface" {
+		f.body = append(f.body, xmlStmt(root))
+		return f
+	}
+	if domain := root.Attr("domain"); domain != "" {
+		f.body = append(f.body, &stmt{kind: "domain", value: value{text: domain}})
+	}
+	for _, n := range root.Children {
+		st := &stmt{kind: n.Name}
+		switch {
+		case n.Name == "requires" && hasAttrs(n, "lib", "version") && len(n.Children) == 0:
+			st.name, st.id = n.Attr("lib"), n.Attr("version")
+
+		case n.Name == "object":
+			st = decompileObject(n)
+
+		case n.Name == "template" && hasAttrs(n, "class", "parent") && reClass.MatchString(n.Attr("class")):
+			st.name, st.id = n.Attr("class"), className(n.Attr("parent"))
+			if st.id == "" || !decompileBody(st, n) {
+				st = nil
+			}
+
+		case n.Name == "menu" && hasAttrs(n, "id") && decompileMenu(st, n):
+
+		default:
+			st = nil
+		}
+		if st == nil {
+			st = xmlStmt(n)
+		}
+		f.body = append(f.body, st)
+	}
+	return f
+}
+
+// decompileObject converts the object, or returns nil if it can't.
+func decompileObject(n *uixml.Node) *stmt {
+	st := &stmt{kind: "object", name: className(n.Attr("class")), id: n.Attr("id")}
+	if n.Name != "object" || !hasAttrs(n, "class", "id") || st.name == "" || !decompileBody(st, n) {
+		return nil
+	}
+	return st
+}
+
+// decompileBody converts the elements of the object or template. Elements that
+// can't be converted are kept as xml. Returns false if the object has text.
+func decompileBody(st *stmt, n *uixml.Node) bool {
+	if n.Text != "" {
+		return false
+	}
+	for _, c := range n.Children {
+		var child *stmt
+		switch c.Name {
+		case "property":
+			child = decompileProperty(c)
+
+		case "signal":
+			ok := hasAttrs(c, "name", "handler", "swapped", "after") && c.Text == "" && len(c.Children) == 0 &&
+				reSignal.MatchString(c.Attr("name")) && reName.MatchString(c.Attr("handler"))
+			words := []string{c.Attr("handler")}
+			for _, flag := range []string{"swapped", "after"} {
+				switch c.Attr(flag) {
+				case "yes":
+					words = append(words, flag)
+				case "":
+				default:
+					ok = false
+				}
+			}
+			if ok {
+				child = &stmt{kind: "signal", name: c.Attr("name"), words: words}
+			}
+
+		case "style":
+			child = &stmt{kind: "styles"}
+			for _, class := range c.Children {
+				if class.Name != "class" || !hasAttrs(class, "name") || class.Attr("name") == "" || class.Text != "" || len(class.Children) > 0 {
+					child = nil
+					break
+				}
+				child.words = append(child.words, class.Attr("name"))
+			}
+			if len(c.Attrs) > 0 || len(c.Children) == 0 {
+				child = nil
+			}
+
+		case "layout":
+			child = &stmt{kind: "layout"}
+			for _, prop := range c.Children {
+				p := decompileProperty(prop)
+				if prop.Name != "property" || p == nil || p.value.object != nil || p.value.bind != nil {
+					child = nil
+					break
+				}
+				child.body = append(child.body, p)
+			}
+			if len(c.Attrs) > 0 || c.Text != "" {
+				child = nil
+			}
+
+		case "child":
+			if len(c.Children) == 1 && len(c.Attrs) <= 1 && (len(c.Attrs) == 0 || hasAttrs(c, "type") || hasAttrs(c, "internal-child")) {
+				child = decompileObject(c.Children[0])
+			}
+			if child != nil {
+				child.blank = len(st.body) > 0
+				if len(c.Attrs) > 0 {
+					child.child = []string{c.Attrs[0].Name.Local, c.Attrs[0].Value}
+				}
+			}
+		}
+		if child == nil {
+			child = xmlStmt(c)
+		}
+		st.body = append(st.body, child)
+	}
+	return true
+}
+
+// decompileProperty converts the property or attribute, or returns nil if it
+// can't.
+func decompileProperty(n *uixml.Node) *stmt {
+	st := &stmt{kind: n.Name, name: n.Attr("name")}
+	if !reName.MatchString(st.name) {
+		return nil
+	}
+	switch {
+	case len(n.Children) == 1 && len(n.Attrs) == 1 && n.Text == "":
+		st.value.object = decompileObject(n.Children[0])
+		if st.value.object == nil {
+			return nil
+		}
+
+	case n.HasAttr("bind-source"):
+		st.value.bind = []string{n.Attr("bind-source"), n.Attr("bind-property")}
+		if flags := n.Attr("bind-flags"); flags != "" {
+			st.value.bind = append(st.value.bind, strings.Split(flags, "|")...)
+		}
+		if !hasAttrs(n, "name", "bind-source", "bind-property", "bind-flags") || n.Text != "" || len(n.Children) > 0 || !reWord.MatchString(strings.Join(st.value.bind[:2], ".")) {
+			return nil
+		}
+		for _, word := range st.value.bind {
+			if !reWord.MatchString(word) || strings.Contains(st.value.bind[1], ".") {
+				return nil
+			}
+		}
+
+	case len(n.Children) == 0 && hasAttrs(n, "name", "translatable", "context"):
+		st.value = value{text: n.Text, context: n.Attr("context"), i18n: n.Attr("translatable") == "yes"}
+		if !st.value.i18n && (n.HasAttr("translatable") || n.HasAttr("context")) {
+			return nil
+		}
+
+	default:
+		return nil
+	}
+	return st
+}
+
+// decompileMenu converts the menu, section, submenu or item block. Returns
+// false if it can't.
+func decompileMenu(st *stmt, n *uixml.Node) bool {
+	st.kind, st.id = n.Name, n.Attr("id")
+	if n.Text != "" {
+		return false
+	}
+	for _, c := range n.Children {
+		child := &stmt{}
+		switch c.Name {
+		case "section", "submenu", "item":
+			if !hasAttrs(c, "id") || !decompileMenu(child, c) {
+				child = nil
+			}
+
+		case "attribute":
+			child = decompileProperty(c)
+			if child != nil && (child.value.object != nil || child.value.bind != nil) {
+				child = nil
+			}
+
+		default:
+			child = nil
+		}
+		if child == nil {
+			child = xmlStmt(c)
+		}
+		st.body = append(st.body, child)
+	}
+	return true
+}
+
+// className returns the name of the class in the syntax, or an empty string
+// if it can't be written.
+func className(class string) string {
+	switch {
+	case !reClass.MatchString(class):
+		return ""
+	case strings.HasPrefix(class, "Gtk") && len(class) > 3 && class[3] >= 'A' && class[3] <= 'Z':
+		return class[3:]
+	}
+	return "$" + class
+}
+
+// hasAttrs returns true if the element has only attributes of the list.
+func hasAttrs(n *uixml.Node, names ...string) bool {
+	for _, attr := range n.Attrs {
+		found := false
+		for _, name := range names {
+			found = found || attr.Name.Space == "" && attr.Name.Local == name
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// xmlStmt returns the element as xml statement.
+func xmlStmt(n *uixml.Node) *stmt {
+	str := strings.TrimPrefix(n.String(), uixml.Header)
+	return &stmt{kind: "xml", src: token{kind: tokString, text: strings.TrimSuffix(str, "\n"), raw: true}}
+}
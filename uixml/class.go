@@ -0,0 +1,78 @@
+package uixml
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Packages maps class name prefixes to their gotk4 package name, checked in
+// order. The prefix is removed from the type name, except for gdkpixbuf.
+var Packages = []struct{ Prefix, Package string }{
+	{"GdkPixbuf", "gdkpixbuf"},
+	{"Gtk", "gtk"},
+	{"Gdk", "gdk"},
+	{"G", "gio"},
+}
+
+// StockIcons gives the named icon replacing common gtk3 stock icons.
+var StockIcons = map[string]string{
+	"gtk-about": "help-about", "gtk-add": "list-add", "gtk-close": "window-close",
+	"gtk-copy": "edit-copy", "gtk-cut": "edit-cut", "gtk-delete": "edit-delete",
+	"gtk-find": "edit-find", "gtk-help": "help-browser", "gtk-new": "document-new",
+	"gtk-open": "document-open", "gtk-paste": "edit-paste", "gtk-quit": "application-exit",
+	"gtk-redo": "edit-redo", "gtk-remove": "list-remove", "gtk-save": "document-save",
+	"gtk-undo": "edit-undo", "gtk-clear": "edit-clear", "gtk-select-all": "edit-select-all",
+	"gtk-go-back": "go-previous", "gtk-go-forward": "go-next", "gtk-home": "go-home",
+	"gtk-refresh": "view-refresh", "gtk-stop": "process-stop", "gtk-print": "document-print",
+	"gtk-save-as": "document-save-as", "gtk-properties": "document-properties",
+	"gtk-preferences": "preferences-system", "gtk-zoom-in": "zoom-in", "gtk-zoom-out": "zoom-out",
+	"gtk-dialog-error": "dialog-error", "gtk-dialog-info": "dialog-information",
+	"gtk-dialog-question": "dialog-question", "gtk-dialog-warning": "dialog-warning",
+}
+
+// GoType returns the gotk4 package and type name of a GType class name, like
+// gtk, Button for GtkButton. Returns empty strings for unknown libraries.
+func GoType(class string) (pkg, name string) {
+	for _, p := range Packages {
+		if p.Package == "gdkpixbuf" && strings.HasPrefix(class, p.Prefix) {
+			return p.Package, strings.TrimPrefix(class, "Gdk") // GdkPixbufLoader is gdkpixbuf.PixbufLoader.
+		}
+		name = strings.TrimPrefix(class, p.Prefix)
+		if name != class && name != "" && name[0] >= 'A' && name[0] <= 'Z' {
+			return p.Package, name
+		}
+	}
+	return "", ""
+}
+
+// GoName converts an object id to an exported Go name: main-box is MainBox.
+func GoName(id string) string {
+	var out []rune
+	upper := true
+	for _, r := range id {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			upper = true
+		case upper:
+			out = append(out, unicode.ToUpper(r))
+			upper = false
+		default:
+			out = append(out, r)
+		}
+	}
+	if len(out) == 0 || !unicode.IsLetter(out[0]) {
+		out = append([]rune("Obj"), out...)
+	}
+	return string(out)
+}
+
+// UniqueName adds a number to the name if already in names, and adds it.
+func UniqueName(names map[string]bool, name string) string {
+	unique := name
+	for i := 2; names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	names[unique] = true
+	return unique
+}
@@ -0,0 +1,408 @@
+// Package uixml reads and writes gtk.Builder interface files.
+//
+// The document is loaded as a simple tree of Node, keeping the position of
+// each element in the source file, so it can be checked or edited before being
+// given to gtk.Builder.
+package uixml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Header is the XML declaration written before the document.
+var Header = `<?xml version="1.0" encoding="UTF-8"?>` + "\n"
+
+// Indent is the indentation used to write each element level.
+var Indent = "  "
+
+// Errors formating.
+var (
+	FmtErrSyntax = "%s: %s"                       // Format: position, message
+	FmtErrEndTag = "element <%s> closed by </%s>" // Format: start, end
+	TxtErrEOF    = "unexpected end of document"
+	TxtErrEmpty  = "no root element found"
+	TxtErrRoots  = "multiple root elements"
+)
+
+//
+//--------------------------------------------------------------------[ NODE ]--
+
+// Pos defines a position in a source file.
+type Pos struct {
+	File string
+	Line int // Starts at 1, 0 if unknown.
+	Col  int // Starts at 1, 0 if unknown.
+}
+
+// String formats the position as file:line:col, dropping unknown parts.
+func (p Pos) String() string {
+	str := p.File
+	if str == "" {
+		str = "<input>"
+	}
+	if p.Line > 0 {
+		str += fmt.Sprintf(":%d", p.Line)
+		if p.Col > 0 {
+			str += fmt.Sprintf(":%d", p.Col)
+		}
+	}
+	return str
+}
+
+// Node defines an XML element of the interface document.
+type Node struct {
+	Name     string     // Element name: interface, object, property, child...
+	Attrs    []xml.Attr // Attributes in source order.
+	Children []*Node    // Child elements.
+	Text     string     // Character data, only kept on elements without children.
+	Pos      Pos        // Position of the element in the source.
+}
+
+// New creates a node with attributes provided as name, value pairs.
+func New(name string, attrs ...string) *Node {
+	n := &Node{Name: name}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		n.SetAttr(attrs[i], attrs[i+1])
+	}
+	return n
+}
+
+// Attr returns the value of the named attribute, or an empty string.
+func (n *Node) Attr(name string) string {
+	for _, attr := range n.Attrs {
+		if attrName(attr.Name) == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// HasAttr returns true if the named attribute is defined.
+func (n *Node) HasAttr(name string) bool {
+	for _, attr := range n.Attrs {
+		if attrName(attr.Name) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetAttr sets the value of the named attribute, adding it if needed.
+func (n *Node) SetAttr(name, value string) {
+	for i, attr := range n.Attrs {
+		if attrName(attr.Name) == name {
+			n.Attrs[i].Value = value
+			return
+		}
+	}
+	n.Attrs = append(n.Attrs, xml.Attr{Name: xmlName(name), Value: value})
+}
+
+// DelAttr removes the named attribute.
+func (n *Node) DelAttr(name string) {
+	for i, attr := range n.Attrs {
+		if attrName(attr.Name) == name {
+			n.Attrs = append(n.Attrs[:i], n.Attrs[i+1:]...)
+			return
+		}
+	}
+}
+
+// Append adds child nodes.
+func (n *Node) Append(children ...*Node) *Node {
+	n.Children = append(n.Children, children...)
+	return n
+}
+
+// Elements returns the direct children with the given element name.
+func (n *Node) Elements(name string) (list []*Node) {
+	for _, child := range n.Children {
+		if child.Name == name {
+			list = append(list, child)
+		}
+	}
+	return list
+}
+
+// Walk calls the function for the node and all its descendants, in document
+// order. Return false to skip the children of the node.
+func (n *Node) Walk(call func(n, parent *Node) bool) {
+	n.walk(nil, call)
+}
+
+func (n *Node) walk(parent *Node, call func(n, parent *Node) bool) {
+	if !call(n, parent) {
+		return
+	}
+	for _, child := range n.Children {
+		child.walk(n, call)
+	}
+}
+
+// Objects returns the node and all descendant object elements, in document
+// order.
+func (n *Node) Objects() (list []*Node) {
+	n.Walk(func(n, _ *Node) bool {
+		if n.Name == "object" {
+			list = append(list, n)
+		}
+		return true
+	})
+	return list
+}
+
+// Lookup returns the first element with the given id, or nil.
+func (n *Node) Lookup(id string) (found *Node) {
+	n.Walk(func(n, _ *Node) bool {
+		if found == nil && n.Attr("id") == id && n.Name != "property" {
+			found = n
+		}
+		return found == nil
+	})
+	return found
+}
+
+// IDs returns the sorted list of ids defined in the document.
+func (n *Node) IDs() (list []string) {
+	n.Walk(func(n, _ *Node) bool {
+		if id := n.Attr("id"); id != "" && n.Name != "property" {
+			list = append(list, id)
+		}
+		return true
+	})
+	sort.Strings(list)
+	return list
+}
+
+// Copy returns a deep copy of the node.
+func (n *Node) Copy() *Node {
+	dup := *n
+	dup.Attrs = append([]xml.Attr(nil), n.Attrs...)
+	dup.Children = make([]*Node, len(n.Children))
+	for i, child := range n.Children {
+		dup.Children[i] = child.Copy()
+	}
+	return &dup
+}
+
+//
+//------------------------------------------------------------------[ ERRORS ]--
+
+// SyntaxError defines a malformed document error.
+type SyntaxError struct {
+	Pos Pos
+	Msg string
+}
+
+func (e *SyntaxError) Error() string { return fmt.Sprintf(FmtErrSyntax, e.Pos, e.Msg) }
+
+//
+//-------------------------------------------------------------------[ PARSE ]--
+
+// ParseFile parses the interface file, in XML or a format of Parsers.
+func ParseFile(file string) (*Node, error) {
+	data, e := os.ReadFile(file)
+	if e != nil {
+		return nil, e
+	}
+	return ParseFormat(data, file)
+}
+
+// ParseString parses the interface string. The file name is used for positions.
+func ParseString(str, file string) (*Node, error) { return ParseBytes([]byte(str), file) }
+
+// Parse parses the interface from the reader. The file name is used for
+// positions.
+func Parse(r io.Reader, file string) (*Node, error) {
+	data, e := io.ReadAll(r)
+	if e != nil {
+		return nil, e
+	}
+	return ParseBytes(data, file)
+}
+
+// ParseBytes parses the interface data. The file name is used for positions.
+//
+// Returns the root element, usually <interface>.
+func ParseBytes(data []byte, file string) (*Node, error) {
+	posAt := positions(data, file)
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	var root *Node
+	var stack []*Node
+	for {
+		offset := dec.InputOffset()
+		tok, e := dec.RawToken()
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			if se, ok := e.(*xml.SyntaxError); ok {
+				return nil, &SyntaxError{Pos: Pos{File: file, Line: se.Line}, Msg: se.Msg}
+			}
+			return nil, &SyntaxError{Pos: posAt(offset), Msg: e.Error()}
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &Node{Name: attrName(tok.Name), Attrs: tok.Copy().Attr, Pos: posAt(offset)}
+			switch {
+			case len(stack) > 0:
+				top := stack[len(stack)-1]
+				top.Children = append(top.Children, n)
+			case root == nil:
+				root = n
+			default:
+				return nil, &SyntaxError{Pos: n.Pos, Msg: TxtErrRoots}
+			}
+			stack = append(stack, n)
+
+		case xml.EndElement:
+			name := attrName(tok.Name)
+			if len(stack) == 0 {
+				return nil, &SyntaxError{Pos: posAt(offset), Msg: fmt.Sprintf(FmtErrEndTag, "", name)}
+			}
+			top := stack[len(stack)-1]
+			if name != top.Name {
+				return nil, &SyntaxError{Pos: posAt(offset), Msg: fmt.Sprintf(FmtErrEndTag, top.Name, name)}
+			}
+			if strings.TrimSpace(top.Text) == "" && (len(top.Children) > 0 || strings.Contains(top.Text, "\n")) {
+				top.Text = "" // Drop indentation.
+			}
+			stack = stack[:len(stack)-1]
+
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += string(tok)
+			}
+		}
+	}
+
+	switch {
+	case len(stack) > 0:
+		return nil, &SyntaxError{Pos: posAt(int64(len(data))), Msg: TxtErrEOF}
+	case root == nil:
+		return nil, &SyntaxError{Pos: Pos{File: file}, Msg: TxtErrEmpty}
+	}
+	return root, nil
+}
+
+// positions returns the func converting byte offsets in the data to positions.
+// Offsets out of the data are clamped.
+func positions(data []byte, file string) func(offset int64) Pos {
+	lines := []int{0} // Offsets of each line start.
+	for i, c := range data {
+		if c == '\n' {
+			lines = append(lines, i+1)
+		}
+	}
+	return func(offset int64) Pos {
+		switch {
+		case offset < 0:
+			offset = 0
+		case offset > int64(len(data)):
+			offset = int64(len(data))
+		}
+		line := sort.Search(len(lines), func(i int) bool { return lines[i] > int(offset) })
+		return Pos{File: file, Line: line, Col: int(offset) - lines[line-1] + 1}
+	}
+}
+
+// OffsetPos returns the position of the byte offset in the data, for errors
+// of other parsers. Offsets out of the data are clamped.
+func OffsetPos(data []byte, file string, offset int64) Pos {
+	return positions(data, file)(offset)
+}
+
+//
+//-------------------------------------------------------------------[ WRITE ]--
+
+// Bytes returns the node as an XML document.
+func (n *Node) Bytes() []byte {
+	data, _ := n.Render()
+	return data
+}
+
+// String returns the node as an XML document.
+func (n *Node) String() string { return string(n.Bytes()) }
+
+// WriteTo writes the node as an XML document.
+func (n *Node) WriteTo(w io.Writer) (int64, error) {
+	data, _ := n.Render()
+	written, e := w.Write(data)
+	return int64(written), e
+}
+
+// Render returns the node as an XML document, with the source position of each
+// written line: lines[0] is the position of the element on line 1.
+func (n *Node) Render() (data []byte, lines []Pos) {
+	var buf bytes.Buffer
+	buf.WriteString(Header)
+	lines = make([]Pos, strings.Count(Header, "\n"))
+	n.write(&buf, &lines, 0)
+	return buf.Bytes(), lines
+}
+
+func (n *Node) write(buf *bytes.Buffer, lines *[]Pos, depth int) {
+	indent := strings.Repeat(Indent, depth)
+	buf.WriteString(indent + "<" + n.Name)
+	for _, attr := range n.Attrs {
+		buf.WriteString(" " + attrName(attr.Name) + `="`)
+		xml.EscapeText(buf, []byte(attr.Value))
+		buf.WriteString(`"`)
+	}
+	*lines = append(*lines, n.Pos) // Escaped text and attributes have no new line.
+
+	switch {
+	case len(n.Children) > 0:
+		buf.WriteString(">\n")
+		for _, child := range n.Children {
+			child.write(buf, lines, depth+1)
+		}
+		buf.WriteString(indent)
+		*lines = append(*lines, n.Pos)
+
+	case n.Text != "":
+		buf.WriteString(">")
+		xml.EscapeText(buf, []byte(n.Text))
+
+	default:
+		buf.WriteString("/>\n")
+		return
+	}
+	buf.WriteString("</" + n.Name + ">\n")
+}
+
+// LinePos returns the source position of the line in rendered lines, or an
+// empty Pos if out of range.
+func LinePos(lines []Pos, line int) Pos {
+	if line < 1 || line > len(lines) {
+		return Pos{}
+	}
+	return lines[line-1]
+}
+
+//
+//-----------------------------------------------------------------[ HELPERS ]--
+
+// attrName formats a raw name with its prefix.
+func attrName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// xmlName splits a prefixed name.
+func xmlName(name string) xml.Name {
+	if i := strings.IndexByte(name, ':'); i >= 0 {
+		return xml.Name{Space: name[:i], Local: name[i+1:]}
+	}
+	return xml.Name{Local: name}
+}
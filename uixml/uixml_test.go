@@ -0,0 +1,264 @@
+package uixml_test
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gtkool4/gtkelp/uixml"
+)
+
+//
+//-------------------------------------------------------------------[ TESTS ]--
+
+func TestParseFile(t *testing.T) {
+	root, e := uixml.ParseFile("testdata/basic.ui")
+	if e != nil {
+		t.Fatal("parse error:", e)
+	}
+	testExpectedString(t, "interface", root.Name)
+	testExpectedString(t, "mainbox,menubar,cut,copy,paste,sep,quit,text",
+		strings.Join(list(root.Objects(), "id"), ","))
+
+	cut := root.Lookup("cut")
+	testExpectedString(t, "testdata/basic.ui:8:11", cut.Pos.String())
+	testExpectedString(t, "GtkButton", cut.Attr("class"))
+	testExpectedString(t, "edit-cut", cut.Elements("property")[0].Text)
+	if root.Lookup("missing") != nil {
+		t.Error("lookup should not find a missing id")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	src := `<?xml version="1.0" encoding="UTF-8"?>
+<interface domain="app">
+  <object class="GtkLabel" id="a&amp;b">
+    <property name="label" translatable="yes">x &lt; y</property>
+    <signal name="activate-link" handler="Open"/>
+  </object>
+</interface>
+`
+	root, e := uixml.ParseString(src, "")
+	if e != nil {
+		t.Fatal("parse error:", e)
+	}
+	testExpectedString(t, src, root.String())
+
+	dup := root.Copy()
+	dup.Lookup("a&b").SetAttr("id", "c")
+	testExpectedString(t, "a&b", root.Objects()[0].Attr("id"))
+	testExpectedString(t, "c", dup.Objects()[0].Attr("id"))
+}
+
+func TestEdit(t *testing.T) {
+	n := uixml.New("object", "class", "GtkBox", "id", "box")
+	n.SetAttr("id", "main")
+	n.DelAttr("class")
+	n.Append(uixml.New("child").Append(uixml.New("object", "class", "GtkLabel")))
+	testExpectedString(t, "main", n.Attr("id"))
+	if n.HasAttr("class") {
+		t.Error("attribute class should be deleted")
+	}
+	testExpectedString(t, "GtkLabel", n.Objects()[1].Attr("class"))
+}
+
+func TestSyntaxError(t *testing.T) {
+	for src, expected := range map[string]string{
+		"":                                "file.ui: " + uixml.TxtErrEmpty,
+		"<interface>\n  <object>":         "file.ui:2:11: " + uixml.TxtErrEOF,
+		"<interface>\n  <child></object>": "file.ui:2:10: element <child> closed by </object>",
+		"<interface/><interface/>":        "file.ui:1:13: " + uixml.TxtErrRoots,
+	} {
+		_, e := uixml.ParseString(src, "file.ui")
+		var se *uixml.SyntaxError
+		if !errors.As(e, &se) {
+			t.Errorf("parse %q should return a *SyntaxError, not %v", src, e)
+			continue
+		}
+		if !strings.HasPrefix(e.Error(), expected) {
+			t.Errorf("parse %q: error should start with %q but we have %q", src, expected, e)
+		}
+	}
+}
+
+func TestGoType(t *testing.T) {
+	for class, expected := range map[string]string{
+		"GtkButton":       "gtk.Button",
+		"GdkPixbuf":       "gdkpixbuf.Pixbuf",
+		"GdkPixbufLoader": "gdkpixbuf.PixbufLoader",
+		"GdkTexture":      "gdk.Texture",
+		"GMenu":           "gio.Menu",
+		"AdwHeaderBar":    ".",
+		"Gtk":             ".",
+	} {
+		pkg, name := uixml.GoType(class)
+		testExpectedString(t, expected, pkg+"."+name)
+	}
+}
+
+func TestGoName(t *testing.T) {
+	for id, expected := range map[string]string{
+		"mainbox":    "Mainbox",
+		"main-box":   "MainBox",
+		"main_box_2": "MainBox2",
+		"2nd":        "Obj2nd",
+		"été":        "Été",
+	} {
+		if have := uixml.GoName(id); have != expected {
+			t.Errorf("Go name of %s should be %s but we have %s", id, expected, have)
+		}
+	}
+
+	names := map[string]bool{}
+	have := []string{uixml.UniqueName(names, "Ok"), uixml.UniqueName(names, "Ok"), uixml.UniqueName(names, "Ok2")}
+	testExpectedString(t, "Ok Ok2 Ok22", strings.Join(have, " "))
+}
+
+func TestYAML(t *testing.T) {
+	files, _ := filepath.Glob("../*/testdata/*.ui") // Round trip keeps the elements order.
+	more, _ := filepath.Glob("../cmd/*/testdata/*.ui")
+	for _, file := range append(files, more...) {
+		root, e := uixml.ParseFile(file)
+		if e != nil {
+			t.Fatal("parse error:", e)
+		}
+		for name, parse := range map[string]func([]byte, string) (*uixml.Node, error){
+			"yaml": uixml.ParseYAML,
+			"json": uixml.ParseJSON,
+		} {
+			data := root.YAML()
+			if name == "json" {
+				data = root.JSON()
+			}
+			back, e := parse(data, "")
+			if e != nil {
+				t.Fatalf("%s %s parse error: %v\n%s", file, name, e, data)
+			}
+			if root.String() != back.String() {
+				t.Errorf("%s %s round trip should keep the interface, have:\n%s", file, name, back)
+			}
+		}
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	src := `requires: {gtk: "4.0"}
+objects:
+  - class: GtkBox
+    id: main
+    children:
+      - class: GtkButton
+        id: ok
+        type: start
+        properties:
+          label: {value: OK, translatable: yes}
+        signals:
+          clicked: Quit
+xml:
+  - |
+    <menu id="app-menu">
+      <item/>
+    </menu>
+`
+	root, e := uixml.ParseYAML([]byte(src), "file.yaml")
+	if e != nil {
+		t.Fatal("parse error:", e)
+	}
+	testExpectedString(t, "main,ok", strings.Join(list(root.Objects(), "id"), ","))
+	ok := root.Lookup("ok")
+	testExpectedString(t, "file.yaml:6:9", ok.Pos.String())
+	testExpectedString(t, "start", root.Lookup("main").Elements("child")[0].Attr("type"))
+	testExpectedString(t, "yes", ok.Elements("property")[0].Attr("translatable"))
+	testExpectedString(t, "Quit", ok.Elements("signal")[0].Attr("handler"))
+	testExpectedString(t, "file.yaml:16:7", root.Lookup("app-menu").Elements("item")[0].Pos.String())
+}
+
+func TestYAMLError(t *testing.T) {
+	for src, expected := range map[string]string{
+		"objects:\n  - id: a":                    "file.yaml:2:5: " + uixml.TxtErrClass,
+		"objects:\n  - class: A\n    size: 1":    "file.yaml:3:5: " + fmt.Sprintf(uixml.FmtErrYAMLKey, "size"),
+		"objects: {class: A}":                    "file.yaml:1:10: " + fmt.Sprintf(uixml.FmtErrYAMLKind, "objects", "sequence"),
+		"- a":                                    "file.yaml:1:1: " + uixml.TxtErrYAMLRoot,
+		"objects: [\n":                           "file.yaml:1: did not find expected node content",
+		"xml:\n  - |\n    <menu>\n\n    </item>": "file.yaml:5:5: " + fmt.Sprintf(uixml.FmtErrYAMLXML, "element <menu> closed by </item>"),
+		"xml: ['<menu></item>']":                 "file.yaml:1:14: " + fmt.Sprintf(uixml.FmtErrYAMLXML, "element <menu> closed by </item>"),
+		"objects:\n  - class: A\n    xml: [1]\n": "file.yaml:3:",
+	} {
+		_, e := uixml.ParseYAML([]byte(src), "file.yaml")
+		var se *uixml.SyntaxError
+		if !errors.As(e, &se) {
+			t.Errorf("parse %q should return a *SyntaxError, not %v", src, e)
+			continue
+		}
+		if !strings.HasPrefix(e.Error(), expected) {
+			t.Errorf("parse %q: error should start with %q but we have %q", src, expected, e)
+		}
+	}
+
+	for src, expected := range map[string]string{
+		"{\n  \"objects\": [}": "file.json:2:15: invalid character '}'",
+		"":                     "file.json:1:1: " + uixml.TxtErrEmpty,
+		`""`:                   "file.json:1:1: " + uixml.TxtErrYAMLRoot,
+		`{"objects": [{"class": "A", "properties": {"label": null}}]}`: "file.json:1:53: " + uixml.TxtErrJSONNull,
+		"{\"objects\": [{\"class\": \"A\",\n  \"class\": \"B\"}]}":     "file.json:2:3: " + fmt.Sprintf(uixml.FmtErrJSONKey, "class"),
+		`{"objects": []} {}`: "file.json:1:17: " + uixml.TxtErrJSONTrailing,
+	} {
+		_, e := uixml.ParseJSON([]byte(src), "file.json")
+		var se *uixml.SyntaxError
+		if !errors.As(e, &se) || !strings.HasPrefix(e.Error(), expected) {
+			t.Errorf("parse json %q: error should start with %q but we have %v", src, expected, e)
+		}
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	root, e := uixml.ParseJSON([]byte(`{"objects": [{"class": "GtkLabel", "id": "path", "properties": {"label": "a\/b", "xalign": 0.5}}]}`), "file.json")
+	if e != nil {
+		t.Fatal("parse error:", e)
+	}
+	label := root.Lookup("path")
+	testExpectedString(t, "file.json:1:14", label.Pos.String())
+	testExpectedString(t, "a/b", label.Elements("property")[0].Text)
+	testExpectedString(t, "0.5", label.Elements("property")[1].Text)
+}
+
+func Example() {
+	root, _ := uixml.ParseString(`<interface><object class="GtkButton" id="ok"/></interface>`, "")
+	for _, obj := range root.Objects() {
+		fmt.Println(obj.Attr("id"), obj.Attr("class"), obj.Pos)
+	}
+	// Output:
+	// ok GtkButton <input>:1:12
+}
+
+//
+//-----------------------------------------------------------------[ HELPERS ]--
+
+func testExpectedString(t *testing.T, expected, have string) {
+	t.Helper()
+	if expected != have {
+		t.Errorf("text should be \"%s\" but we have \"%s\"", expected, have)
+	}
+}
+
+func list(nodes []*uixml.Node, attr string) (out []string) {
+	for _, n := range nodes {
+		out = append(out, n.Attr(attr))
+	}
+	return out
+}
+
+func TestRender(t *testing.T) {
+	root, _ := uixml.ParseString("<interface>\n\n  <object class='GtkBox'>\n<child><object class='GtkLabel'/></child></object></interface>", "a.ui")
+	data, lines := root.Render()
+	expected := []string{"<input>", "a.ui:1:1", "a.ui:3:3", "a.ui:4:1", "a.ui:4:8", "a.ui:4:1", "a.ui:3:3", "a.ui:1:1"}
+	if strings.Count(string(data), "\n") != len(expected) {
+		t.Fatalf("render should have %d lines:\n%s", len(expected), data)
+	}
+	for i, pos := range expected {
+		testExpectedString(t, pos, uixml.LinePos(lines, i+1).String())
+	}
+	testExpectedString(t, "<input>", uixml.LinePos(lines, 99).String())
+}
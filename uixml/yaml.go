@@ -0,0 +1,804 @@
+package uixml
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The YAML and JSON formats describe the same interface with less noise:
+//
+//   requires: {gtk: "4.0"}
+//   objects:
+//     - class: GtkBox
+//       id: main
+//       properties:
+//         orientation: vertical
+//         tooltip-text: {value: Main box, translatable: yes}
+//       style: [card]
+//       children:
+//         - class: GtkButton
+//           type: start
+//           signals:
+//             clicked: Quit
+//
+// The document keys are domain, requires (versions by library), objects,
+// template and xml. Object keys are class, id, properties, signals, style,
+// layout, children and xml. Children are objects, with the type and
+// internal-child keys of their <child> element.
+//
+// Properties, signals and layout are mappings by name, of text values or
+// mappings of the element attributes, with the text as value. Properties given
+// as mappings with a class are objects. Signals given as text are handlers.
+//
+// Other elements, like menus, are kept as XML strings in xml lists, after the
+// other elements. Top-level elements followed by objects, and children that
+// can't be converted, stay in place as a mapping with only an xml key:
+//
+//   children:
+//     - xml: <child type="titlebar">...</child>
+
+// Errors formating.
+var (
+	FmtErrYAMLKey      = "unknown key %s"   // Format: key
+	FmtErrYAMLKind     = "%s must be a %s"  // Format: key, kind
+	FmtErrYAMLXML      = "xml fragment: %s" // Format: error
+	TxtErrYAMLRoot     = "document must be a mapping"
+	FmtErrJSONKey      = "duplicate key %s" // Format: key
+	TxtErrJSONNull     = "null value"
+	TxtErrJSONTrailing = "data after the document"
+	TxtErrClass        = "object needs a class"
+)
+
+//
+//--------------------------------------------------------------[ PARSE YAML ]--
+
+// reYAMLError matches yaml errors: yaml: line 3: message.
+var reYAMLError = regexp.MustCompile(`^yaml: (?:line (\d+): )?(.*)$`)
+
+// ParseYAML parses the interface in YAML format. The file name is used for
+// positions, kept in the nodes.
+func ParseYAML(data []byte, file string) (*Node, error) {
+	var doc yaml.Node
+	if e := yaml.Unmarshal(data, &doc); e != nil {
+		pos, msg := Pos{File: file}, e.Error()
+		if m := reYAMLError.FindStringSubmatch(msg); m != nil {
+			pos.Line, _ = strconv.Atoi(m[1])
+			msg = m[2]
+		}
+		return nil, &SyntaxError{Pos: pos, Msg: msg}
+	}
+	if len(doc.Content) == 0 {
+		return nil, &SyntaxError{Pos: Pos{File: file}, Msg: TxtErrEmpty}
+	}
+	p := yamlParser{file: file, lines: strings.Split(string(data), "\n")}
+	return p.document(doc.Content[0])
+}
+
+// ParseJSON parses the interface in JSON format, the YAML format written as
+// JSON. The file name is used for positions, kept in the nodes.
+//
+// Values can't be null, and keys must be unique in objects.
+func ParseJSON(data []byte, file string) (*Node, error) {
+	j := jsonParser{dec: json.NewDecoder(bytes.NewReader(data)), data: data, posAt: positions(data, file)}
+	j.dec.UseNumber()
+	doc, e := j.value()
+	if e == nil {
+		j.skip()
+		if _, e = j.dec.Token(); e == io.EOF {
+			p := yamlParser{file: file, lines: strings.Split(string(data), "\n")}
+			return p.document(doc)
+		} else if e == nil {
+			e = errors.New(TxtErrJSONTrailing)
+		}
+	}
+
+	if se, ok := e.(*SyntaxError); ok {
+		return nil, se
+	}
+	pos := j.posAt(j.offset)
+	var se *json.SyntaxError
+	switch {
+	case e == io.EOF:
+		e = errors.New(TxtErrEmpty)
+	case errors.As(e, &se):
+		pos = j.posAt(se.Offset - 1) // Offset is after the error.
+	}
+	return nil, &SyntaxError{Pos: pos, Msg: e.Error()}
+}
+
+// jsonParser converts JSON values to YAML nodes, with their positions.
+type jsonParser struct {
+	dec    *json.Decoder
+	data   []byte
+	posAt  func(offset int64) Pos
+	offset int64 // Start of the next token.
+}
+
+// skip sets the offset at the start of the next token.
+func (j *jsonParser) skip() {
+	j.offset = j.dec.InputOffset()
+	for j.offset < int64(len(j.data)) && strings.IndexByte(" \t\r\n:,", j.data[j.offset]) >= 0 {
+		j.offset++
+	}
+}
+
+// value reads the next JSON value as a YAML node.
+func (j *jsonParser) value() (*yaml.Node, error) {
+	j.skip()
+	pos := j.posAt(j.offset)
+	tok, e := j.dec.Token()
+	if e != nil {
+		return nil, e
+	}
+	n := &yaml.Node{Kind: yaml.ScalarNode, Line: pos.Line, Column: pos.Col}
+	switch tok := tok.(type) {
+	case string:
+		n.Value, n.Style = tok, yaml.DoubleQuotedStyle
+	case json.Number:
+		n.Value = tok.String()
+	case bool:
+		n.Value = strconv.FormatBool(tok)
+	case nil:
+		return nil, &SyntaxError{Pos: pos, Msg: TxtErrJSONNull}
+
+	case json.Delim:
+		n.Kind = yaml.SequenceNode
+		if tok == '{' {
+			n.Kind = yaml.MappingNode
+		}
+		keys := map[string]bool{}
+		for j.dec.More() {
+			if n.Kind == yaml.MappingNode {
+				key, e := j.value()
+				if e != nil {
+					return nil, e
+				}
+				if keys[key.Value] {
+					return nil, &SyntaxError{Pos: Pos{File: pos.File, Line: key.Line, Col: key.Column}, Msg: fmt.Sprintf(FmtErrJSONKey, key.Value)}
+				}
+				keys[key.Value] = true
+				n.Content = append(n.Content, key)
+			}
+			item, e := j.value()
+			if e != nil {
+				return nil, e
+			}
+			n.Content = append(n.Content, item)
+		}
+		j.skip()
+		if _, e := j.dec.Token(); e != nil { // Closing delimiter.
+			return nil, e
+		}
+	}
+	return n, nil
+}
+
+// Parsers lists the parsers of other formats than XML, by file extension.
+var Parsers = map[string]func(data []byte, file string) (*Node, error){
+	".json": ParseJSON,
+	".yaml": ParseYAML,
+	".yml":  ParseYAML,
+}
+
+// ParseFormat parses the interface data with the parser of the file extension,
+// or as XML.
+func ParseFormat(data []byte, file string) (*Node, error) {
+	if parse, ok := Parsers[filepath.Ext(file)]; ok {
+		return parse(data, file)
+	}
+	return ParseBytes(data, file)
+}
+
+type yamlParser struct {
+	file  string
+	lines []string // Source lines, for the indentation of xml blocks.
+}
+
+func (p *yamlParser) pos(n *yaml.Node) Pos { return Pos{File: p.file, Line: n.Line, Col: n.Column} }
+
+func (p *yamlParser) errorf(n *yaml.Node, format string, args ...interface{}) error {
+	return &SyntaxError{Pos: p.pos(n), Msg: fmt.Sprintf(format, args...)}
+}
+
+// pairs calls fn for each key and value of the mapping.
+func (p *yamlParser) pairs(n *yaml.Node, name string, fn func(key, value *yaml.Node) error) error {
+	if n.Kind != yaml.MappingNode {
+		return p.errorf(n, FmtErrYAMLKind, name, "mapping")
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if e := fn(n.Content[i], n.Content[i+1]); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// items calls fn for each item of the sequence.
+func (p *yamlParser) items(n *yaml.Node, name string, fn func(item *yaml.Node) error) error {
+	if n.Kind != yaml.SequenceNode {
+		return p.errorf(n, FmtErrYAMLKind, name, "sequence")
+	}
+	for _, item := range n.Content {
+		if e := fn(item); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func (p *yamlParser) scalar(n *yaml.Node, name string) (string, error) {
+	if n.Kind != yaml.ScalarNode {
+		return "", p.errorf(n, FmtErrYAMLKind, name, "text")
+	}
+	return n.Value, nil
+}
+
+// document converts the root mapping to an <interface> element.
+func (p *yamlParser) document(n *yaml.Node) (*Node, error) {
+	if n.Kind != yaml.MappingNode {
+		return nil, p.errorf(n, TxtErrYAMLRoot)
+	}
+	root := &Node{Name: "interface", Pos: p.pos(n)}
+	var raw []*Node
+	e := p.pairs(n, "document", func(key, value *yaml.Node) (e error) {
+		switch key.Value {
+		case "domain":
+			var domain string
+			domain, e = p.scalar(value, key.Value)
+			root.SetAttr("domain", domain)
+
+		case "requires":
+			e = p.pairs(value, key.Value, func(lib, version *yaml.Node) error {
+				str, e := p.scalar(version, lib.Value)
+				req := &Node{Name: "requires", Pos: p.pos(lib)}
+				req.SetAttr("lib", lib.Value)
+				req.SetAttr("version", str)
+				root.Append(req)
+				return e
+			})
+
+		case "objects":
+			e = p.items(value, key.Value, func(item *yaml.Node) error {
+				if frag, ok := xmlItem(item); ok {
+					obj, e := p.fragment(frag)
+					if e == nil {
+						root.Append(obj)
+					}
+					return e
+				}
+				obj, e := p.object(item, "object")
+				root.Append(obj)
+				return e
+			})
+
+		case "template":
+			var obj *Node
+			obj, e = p.object(value, "template")
+			root.Append(obj)
+
+		case "xml":
+			raw, e = p.xml(value)
+
+		default:
+			e = p.errorf(key, FmtErrYAMLKey, key.Value)
+		}
+		return e
+	})
+	return root.Append(raw...), e
+}
+
+// object converts the mapping to an <object> or <template> element.
+func (p *yamlParser) object(n *yaml.Node, name string) (*Node, error) {
+	obj := &Node{Name: name, Pos: p.pos(n)}
+	var style *Node
+	var children, raw []*Node
+	e := p.pairs(n, name, func(key, value *yaml.Node) (e error) {
+		switch key.Value {
+		case "class", "id", "parent":
+			var str string
+			str, e = p.scalar(value, key.Value)
+			obj.SetAttr(key.Value, str)
+
+		case "properties", "signals", "layout":
+			elem, parent := map[string]string{"properties": "property", "signals": "signal"}[key.Value], obj
+			if key.Value == "layout" {
+				elem, parent = "property", &Node{Name: "layout", Pos: p.pos(key)}
+				obj.Append(parent)
+			}
+			e = p.pairs(value, key.Value, func(k, v *yaml.Node) error {
+				child, e := p.element(elem, k, v)
+				parent.Append(child)
+				return e
+			})
+
+		case "style":
+			style = &Node{Name: "style", Pos: p.pos(key)}
+			e = p.items(value, key.Value, func(item *yaml.Node) error {
+				class, e := p.scalar(item, key.Value)
+				n := &Node{Name: "class", Pos: p.pos(item)}
+				n.SetAttr("name", class)
+				style.Append(n)
+				return e
+			})
+
+		case "children":
+			e = p.items(value, key.Value, func(item *yaml.Node) error {
+				child, e := p.child(item)
+				if child != nil {
+					children = append(children, child)
+				}
+				return e
+			})
+
+		case "xml":
+			raw, e = p.xml(value)
+
+		default:
+			e = p.errorf(key, FmtErrYAMLKey, key.Value)
+		}
+		return e
+	})
+	if e == nil && obj.Attr("class") == "" {
+		e = p.errorf(n, TxtErrClass)
+	}
+	if style != nil {
+		obj.Append(style)
+	}
+	return obj.Append(children...).Append(raw...), e
+}
+
+// child converts the mapping to a <child> element with its object. The type
+// and internal-child keys are attributes of the <child>. A child kept as XML
+// is parsed.
+func (p *yamlParser) child(n *yaml.Node) (*Node, error) {
+	if frag, ok := xmlItem(n); ok {
+		return p.fragment(frag)
+	}
+	child := &Node{Name: "child", Pos: p.pos(n)}
+	if n.Kind != yaml.MappingNode {
+		return child, p.errorf(n, FmtErrYAMLKind, "child", "mapping")
+	}
+	objYAML := &yaml.Node{Kind: yaml.MappingNode, Line: n.Line, Column: n.Column}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		switch key.Value {
+		case "type", "internal-child":
+			str, e := p.scalar(value, key.Value)
+			if e != nil {
+				return child, e
+			}
+			child.SetAttr(key.Value, str)
+
+		default:
+			objYAML.Content = append(objYAML.Content, key, value)
+		}
+	}
+	obj, e := p.object(objYAML, "object")
+	return child.Append(obj), e
+}
+
+// element converts a property, signal or layout property. Mappings are the
+// element attributes with the text as value, or an object.
+func (p *yamlParser) element(name string, key, value *yaml.Node) (*Node, error) {
+	n := &Node{Name: name, Pos: p.pos(key)}
+	n.SetAttr("name", key.Value)
+	switch value.Kind {
+	case yaml.ScalarNode:
+		if name == "signal" {
+			n.SetAttr("handler", value.Value)
+		} else {
+			n.Text = value.Value
+		}
+		return n, nil
+
+	case yaml.MappingNode:
+		if name == "property" && hasKey(value, "class") {
+			obj, e := p.object(value, "object")
+			return n.Append(obj), e
+		}
+		e := p.pairs(value, key.Value, func(k, v *yaml.Node) error {
+			str, e := p.scalar(v, k.Value)
+			if k.Value == "value" {
+				n.Text = str
+			} else {
+				n.SetAttr(k.Value, str)
+			}
+			return e
+		})
+		return n, e
+	}
+	return n, p.errorf(value, FmtErrYAMLKind, key.Value, "text or mapping")
+}
+
+// xml parses the list of XML fragments.
+func (p *yamlParser) xml(n *yaml.Node) (list []*Node, e error) {
+	e = p.items(n, "xml", func(item *yaml.Node) error {
+		frag, e := p.fragment(item)
+		if e == nil {
+			list = append(list, frag)
+		}
+		return e
+	})
+	return list, e
+}
+
+// fragment parses the XML fragment, with positions in the YAML source.
+func (p *yamlParser) fragment(item *yaml.Node) (*Node, error) {
+	str, e := p.scalar(item, "xml")
+	if e != nil {
+		return nil, e
+	}
+	frag, e := ParseString(str, p.file)
+	if e != nil {
+		se := e.(*SyntaxError)
+		return nil, &SyntaxError{Pos: p.shift(item, se.Pos), Msg: fmt.Sprintf(FmtErrYAMLXML, se.Msg)}
+	}
+	frag.Walk(func(n, _ *Node) bool {
+		n.Pos = p.shift(item, n.Pos)
+		return true
+	})
+	return frag, nil
+}
+
+// shift converts a position in the xml fragment to the YAML source. Positions
+// are exact for blocks and single line strings.
+func (p *yamlParser) shift(item *yaml.Node, pos Pos) Pos {
+	if pos.Line == 0 {
+		return p.pos(item)
+	}
+	switch {
+	case item.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0: // Content starts on the next line.
+		if item.Line < len(p.lines) { // Indentation of the first line.
+			line := p.lines[item.Line]
+			pos.Col += len(line) - len(strings.TrimLeft(line, " "))
+		}
+		pos.Line += item.Line
+		return pos
+
+	case pos.Line == 1 && item.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle) != 0:
+		pos.Col++
+		fallthrough
+
+	case pos.Line == 1:
+		pos.Col += item.Column - 1
+	}
+	pos.Line += item.Line - 1
+	return pos
+}
+
+// xmlItem returns the value of the mapping if its only key is xml: an object
+// or child kept in place as XML.
+func xmlItem(n *yaml.Node) (*yaml.Node, bool) {
+	if n.Kind != yaml.MappingNode || len(n.Content) != 2 || n.Content[0].Value != "xml" {
+		return nil, false
+	}
+	return n.Content[1], true
+}
+
+func hasKey(n *yaml.Node, key string) bool {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return true
+		}
+	}
+	return false
+}
+
+//
+//--------------------------------------------------------------[ WRITE YAML ]--
+
+// YAML returns the interface in YAML format.
+func (n *Node) YAML() []byte {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	enc.Encode(n.yamlDocument())
+	enc.Close()
+	return buf.Bytes()
+}
+
+// JSON returns the interface in JSON format.
+func (n *Node) JSON() []byte {
+	var buf bytes.Buffer
+	writeJSON(&buf, n.yamlDocument(), "")
+	buf.WriteString("\n")
+	return buf.Bytes()
+}
+
+// yamlDocument converts the <interface> element.
+func (n *Node) yamlDocument() *yaml.Node {
+	doc := yamlMap()
+	requires := yamlMap()
+	objects := &yaml.Node{Kind: yaml.SequenceNode}
+	var template *Node
+	templateAt := 0 // Index in objects.
+	var raw []*Node
+	if domain := n.Attr("domain"); domain != "" {
+		yamlAdd(doc, "domain", yamlText(domain))
+	}
+	for _, child := range n.Children {
+		switch {
+		case child.Name == "requires" && len(child.Attrs) == 2 && child.Attr("lib") != "":
+			version := yamlText(child.Attr("version"))
+			version.Style = yaml.DoubleQuotedStyle // Not a number.
+			yamlAdd(requires, child.Attr("lib"), version)
+		case child.Name == "object" && child.yamlable():
+			objects.Content = append(yamlXMLItems(objects.Content, raw), child.yamlObject())
+			raw = nil
+		case child.Name == "template" && child.yamlable() && template == nil:
+			objects.Content = yamlXMLItems(objects.Content, raw)
+			template, templateAt, raw = child, len(objects.Content), nil
+		default: // Kept in place if objects follow.
+			raw = append(raw, child)
+		}
+	}
+	if len(requires.Content) > 0 {
+		yamlAdd(doc, "requires", requires)
+	}
+
+	// The template key is before or after the objects, or kept in place.
+	switch {
+	case template == nil:
+	case templateAt == 0:
+		yamlAdd(doc, "template", template.yamlObject())
+		template = nil
+	case templateAt < len(objects.Content):
+		item := yamlXMLItem(template)
+		objects.Content = append(objects.Content[:templateAt], append([]*yaml.Node{item}, objects.Content[templateAt:]...)...)
+		template = nil
+	}
+	if len(objects.Content) > 0 {
+		yamlAdd(doc, "objects", objects)
+	}
+	if template != nil {
+		yamlAdd(doc, "template", template.yamlObject())
+	}
+	yamlAddXML(doc, raw)
+	return doc
+}
+
+// yamlable returns true if the object or template attributes have keys.
+func (n *Node) yamlable() bool {
+	for _, attr := range n.Attrs {
+		switch attrName(attr.Name) {
+		case "class", "id", "parent":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// yamlObject converts the <object> or <template> element.
+func (n *Node) yamlObject() *yaml.Node {
+	obj := yamlMap()
+	for _, attr := range n.Attrs {
+		yamlAdd(obj, attrName(attr.Name), yamlText(attr.Value))
+	}
+	groups := map[string]*yaml.Node{}
+	group := func(key string) *yaml.Node {
+		if groups[key] == nil {
+			groups[key] = yamlMap()
+			if key == "style" || key == "children" {
+				groups[key].Kind = yaml.SequenceNode
+			}
+		}
+		return groups[key]
+	}
+	var raw []*Node
+	for _, child := range n.Children {
+		switch {
+		case child.Name == "property" && child.yamlElement():
+			yamlAdd(group("properties"), child.Attr("name"), child.yamlValue())
+
+		case child.Name == "signal" && child.yamlElement():
+			yamlAdd(group("signals"), child.Attr("name"), child.yamlValue())
+
+		case child.Name == "layout" && len(child.Attrs) == 0 && allYAML(child.Children, "property"):
+			for _, p := range child.Children {
+				yamlAdd(group("layout"), p.Attr("name"), p.yamlValue())
+			}
+
+		case child.Name == "style" && len(child.Attrs) == 0 && allYAML(child.Children, "class"):
+			for _, class := range child.Children {
+				style := group("style")
+				style.Content = append(style.Content, yamlText(class.Attr("name")))
+			}
+
+		case child.Name == "child" && child.yamlChild():
+			obj := child.Children[0].yamlObject()
+			at := 2 * len(child.Children[0].Attrs) // Child keys after class and id.
+			var keys []*yaml.Node
+			for _, attr := range child.Attrs {
+				keys = append(keys, yamlText(attrName(attr.Name)), yamlText(attr.Value))
+			}
+			obj.Content = append(obj.Content[:at], append(keys, obj.Content[at:]...)...)
+			children := group("children")
+			children.Content = append(children.Content, obj)
+
+		case child.Name == "child": // Kept in place.
+			children := group("children")
+			children.Content = append(children.Content, yamlXMLItem(child))
+
+		default:
+			raw = append(raw, child)
+		}
+	}
+	for _, key := range []string{"properties", "signals", "style", "layout", "children"} {
+		if groups[key] != nil {
+			yamlAdd(obj, key, groups[key])
+		}
+	}
+	yamlAddXML(obj, raw)
+	return obj
+}
+
+// yamlElement returns true if the property or signal can be converted.
+func (n *Node) yamlElement() bool {
+	if n.Attr("name") == "" || n.HasAttr("value") {
+		return false
+	}
+	switch len(n.Children) {
+	case 0:
+		return true
+	case 1:
+		obj := n.Children[0]
+		return n.Name == "property" && len(n.Attrs) == 1 && n.Text == "" && obj.Name == "object" && obj.yamlable()
+	}
+	return false
+}
+
+// yamlChild returns true if the <child> can be converted to an object.
+func (n *Node) yamlChild() bool {
+	for _, attr := range n.Attrs {
+		switch attrName(attr.Name) {
+		case "type", "internal-child":
+		default:
+			return false
+		}
+	}
+	if len(n.Children) != 1 || n.Children[0].Name != "object" || !n.Children[0].yamlable() {
+		return false
+	}
+	for _, key := range []string{"type", "internal-child"} { // Would be taken as child keys.
+		if n.Children[0].HasAttr(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// yamlValue converts a property, signal or layout property: text, handler,
+// object or mapping of attributes.
+func (n *Node) yamlValue() *yaml.Node {
+	switch {
+	case n.Name == "signal":
+		if len(n.Attrs) == 2 && n.Attr("handler") != "" && n.Text == "" {
+			return yamlText(n.Attr("handler"))
+		}
+	case len(n.Children) == 1:
+		return n.Children[0].yamlObject()
+	case len(n.Attrs) == 1:
+		return yamlText(n.Text)
+	}
+	m := yamlMap()
+	m.Style = yaml.FlowStyle
+	if n.Text != "" {
+		yamlAdd(m, "value", yamlText(n.Text))
+	}
+	for _, attr := range n.Attrs {
+		if name := attrName(attr.Name); name != "name" {
+			yamlAdd(m, name, yamlText(attr.Value))
+		}
+	}
+	return m
+}
+
+// allYAML returns true if the list has only style classes or layout
+// properties that can be converted, by element name.
+func allYAML(list []*Node, name string) bool {
+	for _, n := range list {
+		switch {
+		case n.Name != name:
+			return false
+		case name == "class" && (len(n.Attrs) != 1 || n.Attr("name") == "" || len(n.Children) > 0 || n.Text != ""):
+			return false
+		case name == "property" && (len(n.Children) > 0 || !n.yamlElement()):
+			return false
+		}
+	}
+	return true
+}
+
+func yamlMap() *yaml.Node { return &yaml.Node{Kind: yaml.MappingNode} }
+
+// yamlText returns a text scalar. Values are read as text, so true or 6 don't
+// need quotes.
+func yamlText(str string) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.ScalarNode, Value: str}
+	if strings.Contains(str, "\n") {
+		n.Style = yaml.LiteralStyle
+	}
+	return n
+}
+
+func yamlAdd(m *yaml.Node, key string, value *yaml.Node) {
+	m.Content = append(m.Content, yamlText(key), value)
+}
+
+// yamlAddXML adds the elements to the mapping as XML strings.
+func yamlAddXML(m *yaml.Node, list []*Node) {
+	if len(list) == 0 {
+		return
+	}
+	seq := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, n := range list {
+		seq.Content = append(seq.Content, yamlXML(n))
+	}
+	yamlAdd(m, "xml", seq)
+}
+
+// yamlXMLItem returns the mapping keeping the element in place as XML.
+func yamlXMLItem(n *Node) *yaml.Node {
+	m := yamlMap()
+	yamlAdd(m, "xml", yamlXML(n))
+	return m
+}
+
+// yamlXMLItems appends the elements to the sequence content as XML items.
+func yamlXMLItems(content []*yaml.Node, list []*Node) []*yaml.Node {
+	for _, n := range list {
+		content = append(content, yamlXMLItem(n))
+	}
+	return content
+}
+
+func yamlXML(n *Node) *yaml.Node {
+	var buf bytes.Buffer
+	var lines []Pos
+	n.write(&buf, &lines, 0)
+	return yamlText(buf.String())
+}
+
+// writeJSON writes the YAML node as indented JSON. Scalars are strings.
+func writeJSON(buf *bytes.Buffer, n *yaml.Node, indent string) {
+	switch n.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		open, end, step := "{", "}", 2
+		if n.Kind == yaml.SequenceNode {
+			open, end, step = "[", "]", 1
+		}
+		if len(n.Content) == 0 {
+			buf.WriteString(open + end)
+			return
+		}
+		buf.WriteString(open)
+		for i := 0; i < len(n.Content); i += step {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n" + indent + Indent)
+			if step == 2 {
+				writeJSON(buf, n.Content[i], "")
+				buf.WriteString(": ")
+			}
+			writeJSON(buf, n.Content[i+step-1], indent+Indent)
+		}
+		buf.WriteString("\n" + indent + end)
+
+	default:
+		enc := json.NewEncoder(buf)
+		enc.SetEscapeHTML(false)
+		enc.Encode(n.Value)
+		buf.Truncate(buf.Len() - 1) // Newline added by Encode.
+	}
+}